@@ -0,0 +1,168 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// checkTagName is the struct tag listing the async checks of a field.
+const checkTagName = "check"
+
+// CheckFunc is a context-aware validation that may perform I/O, such as a
+// database uniqueness lookup. It returns nil if value is valid and an
+// error describing the violation otherwise.
+type CheckFunc func(ctx context.Context, value any) error
+
+// RegisterCheck registers an async check under tag. Checks are listed in the
+// "check" struct tag and run by [Verifier.StructCtx] after the synchronous
+// "binding" tags, only for fields that passed them and are not nil.
+//
+//	v.RegisterCheck("unique_username", func(ctx context.Context, value any) error {
+//	    taken, err := repo.UsernameExists(ctx, value.(string))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if taken {
+//	        return errors.New("username is taken")
+//	    }
+//	    return nil
+//	})
+//
+//	type SignUp struct {
+//	    Username string `json:"username" binding:"required,min=3" check:"unique_username"`
+//	}
+//
+// A violation is translated with the message registered for tag through
+// [Verifier.AddValidationTranslation], falling back to the returned error's
+// text. If the check's context is done when it returns, the error is
+// treated as a failure to validate rather than a violation.
+func (ver *Verifier) RegisterCheck(tag string, fn CheckFunc) error {
+	if tag == "" || fn == nil {
+		return errors.New("verify: check tag and function must not be empty")
+	}
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	checks := maps.Clone(*ver.checks.Load())
+	checks[tag] = fn
+	ver.checks.Store(&checks)
+	return nil
+}
+
+type checkJob struct {
+	node  *fieldNode
+	value any
+	tags  []string
+}
+
+// runChecks runs the async checks of s and merges their violations into
+// err, the result of the synchronous validation. A check name that is not
+// registered is an error, whether or not the field is checked.
+func (ver *Verifier) runChecks(ctx context.Context, s any, err error) error {
+	if !hasStructTag(reflect.TypeOf(s), checkTagName) {
+		return err
+	}
+	checks := *ver.checks.Load()
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if err != nil && !ok {
+		return err
+	}
+	failed := make(map[string]bool, len(valErrs))
+	for _, fe := range valErrs {
+		failed[fe.StructNamespace()] = true
+	}
+
+	var jobs []checkJob
+	var walkErr error
+	ver.walkFields(s, func(n *fieldNode) bool {
+		tag, ok := n.field.Tag.Lookup(checkTagName)
+		if !ok {
+			return true
+		}
+		tags := splitTag(tag)
+		for _, t := range tags {
+			if _, ok := checks[t]; !ok && walkErr == nil {
+				walkErr = fmt.Errorf("verify: undefined check %q on %s", t, n.ns)
+			}
+		}
+		if failed[n.structNs] || !n.value.CanInterface() {
+			return true
+		}
+		val := n.value
+		for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return true
+			}
+			val = val.Elem()
+		}
+		jobs = append(jobs, checkJob{node: n, value: val.Interface(), tags: tags})
+		return true
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	if len(jobs) == 0 {
+		return err
+	}
+
+	results := make([]validator.FieldError, len(jobs))
+	failures := make([]error, len(jobs))
+	sem := make(chan struct{}, ver.checkLimit)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			failures[i] = ctx.Err()
+		}
+		if failures[i] != nil {
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			results[i], failures[i] = ver.runCheckJob(ctx, checks, job)
+		})
+	}
+	wg.Wait()
+
+	if err := errors.Join(failures...); err != nil {
+		return err
+	}
+	for _, fe := range results {
+		if fe != nil {
+			valErrs = append(valErrs, fe)
+		}
+	}
+	if len(valErrs) == 0 {
+		return nil
+	}
+	return valErrs
+}
+
+// runCheckJob runs the checks of one field in order and stops at the first
+// violation.
+func (ver *Verifier) runCheckJob(ctx context.Context, checks map[string]CheckFunc, job checkJob) (validator.FieldError, error) {
+	for _, tag := range job.tags {
+		checkCtx, cancel := ctx, context.CancelFunc(func() {})
+		if ver.checkTimeout > 0 {
+			checkCtx, cancel = context.WithTimeout(ctx, ver.checkTimeout)
+		}
+		err := checks[tag](checkCtx, job.value)
+		ctxErr := checkCtx.Err()
+		cancel()
+		if err == nil {
+			continue
+		}
+		if ctxErr != nil {
+			return nil, fmt.Errorf("verify: check %q on %s: %w", tag, job.node.ns, ctxErr)
+		}
+		return newFieldError(job.node, tag, "", job.value, err.Error()), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,136 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+// usernames is an in-memory stand-in for a user repository.
+var usernames = map[string]bool{"alice": true, "bob": true}
+
+func uniqueUsername(ctx context.Context, value any) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Millisecond):
+	}
+	if usernames[value.(string)] {
+		return errors.New("username is taken")
+	}
+	return nil
+}
+
+type RegisterParams struct {
+	Username string          `json:"username" binding:"required,min=3" check:"unique_username"`
+	Invitees []InviteeParams `json:"invitees" binding:"dive"`
+}
+
+type InviteeParams struct {
+	Username string `json:"username" check:"unique_username"`
+}
+
+func TestRegisterCheck(t *testing.T) {
+	v := newVerifier(t)
+	if err := v.RegisterCheck("unique_username", uniqueUsername); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.AddValidationTranslation("unique_username", "{0}已被占用"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Struct(RegisterParams{Username: "carol"}); err != nil {
+		t.Fatalf("expected valid, got %v", err)
+	}
+
+	p := RegisterParams{Username: "alice", Invitees: []InviteeParams{{Username: "dave"}, {Username: "bob"}}}
+	all := v.AllFieldErrors(v.StructCtx(context.Background(), p))
+	if all["username"] != "username已被占用" {
+		t.Fatalf("unexpected username message %q", all["username"])
+	}
+	if all["invitees[1].username"] != "username已被占用" {
+		t.Fatalf("expected nested check violation, got %v", all)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 violations, got %v", all)
+	}
+}
+
+func TestRegisterCheck_SkipsFailedFields(t *testing.T) {
+	v := newVerifier(t)
+	var calls atomic.Int32
+	if err := v.RegisterCheck("unique_username", func(ctx context.Context, value any) error {
+		calls.Add(1)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	all := v.AllFieldErrors(v.Struct(RegisterParams{Username: "al"}))
+	if _, ok := all["username"]; !ok {
+		t.Fatalf("expected min violation, got %v", all)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("check should not run on a field that failed its binding tags")
+	}
+}
+
+func TestRegisterCheck_TimeoutAndConcurrency(t *testing.T) {
+	v := verify.MustNew(verify.WithCheckConcurrency(2), verify.WithCheckTimeout(20*time.Millisecond))
+	var running, peak atomic.Int32
+	if err := v.RegisterCheck("unique_username", func(ctx context.Context, value any) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if value == "slow" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	p := RegisterParams{Username: "carol", Invitees: make([]InviteeParams, 6)}
+	for i := range p.Invitees {
+		p.Invitees[i].Username = "user"
+	}
+	if err := v.Struct(p); err != nil {
+		t.Fatal(err)
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent checks, got %d", peak.Load())
+	}
+
+	err := v.Struct(RegisterParams{Username: "slow"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if v.AllFieldErrors(err) != nil {
+		t.Fatal("a timed out check is not a violation")
+	}
+}
+
+func TestRegisterCheck_Undefined(t *testing.T) {
+	v := newVerifier(t)
+	if err := v.RegisterCheck("other", uniqueUsername); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Struct(RegisterParams{Username: "carol"}); err == nil {
+		t.Fatal("expected error for undefined check")
+	}
+	// Also without registered checks and on fields failing their tags.
+	if err := newVerifier(t).Struct(RegisterParams{}); v.AllFieldErrors(err) != nil || err == nil {
+		t.Fatalf("expected error for undefined check, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package verify
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gtkit/goerr"
+)
+
+// codeTag is the struct tag overriding the codes of the violations of a
+// field: code:"NAME_INVALID" for all of them, or
+// code:"required=NAME_MISSING,max=NAME_TOO_LONG" per tag with an optional
+// bare code for the other tags.
+const codeTag = "code"
+
+// codeTable holds the registered codes; it is replaced as a whole.
+type codeTable struct {
+	tags     map[string]string        // tag → code
+	statuses map[string]*goerr.Status // code → status
+}
+
+// WithCodes overrides the codes reported for tags, see [Verifier.Code].
+// Can be repeated.
+//
+//	verify.WithCodes(map[string]string{"required": "MISSING", "e164": "PHONE_INVALID"})
+func WithCodes(codes map[string]string) Option {
+	return func(c *config) {
+		if c.codes == nil {
+			c.codes = make(map[string]string, len(codes))
+		}
+		maps.Copy(c.codes, codes)
+	}
+}
+
+// WithCodeStatuses maps codes to the goerr status of the errors returned by
+// [Verifier.StructErr] and [Verifier.FieldErr] when the reported violation
+// has that code. Default: goerr.StatusValidateParams(). Can be repeated.
+func WithCodeStatuses(statuses map[string]*goerr.Status) Option {
+	return func(c *config) {
+		if c.codeStatuses == nil {
+			c.codeStatuses = make(map[string]*goerr.Status, len(statuses))
+		}
+		maps.Copy(c.codeStatuses, statuses)
+	}
+}
+
+// RegisterCode sets the code reported for violations of tag.
+func (ver *Verifier) RegisterCode(tag, code string) error {
+	if tag == "" || code == "" {
+		return errors.New("verify: code tag and code must not be empty")
+	}
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	t := *ver.codes.Load()
+	t.tags = maps.Clone(t.tags)
+	t.tags[tag] = code
+	ver.codes.Store(&t)
+	return nil
+}
+
+// RegisterCodeStatus sets the goerr status of the errors returned by
+// [Verifier.StructErr] and [Verifier.FieldErr] for violations with code.
+func (ver *Verifier) RegisterCodeStatus(code string, status *goerr.Status) error {
+	if code == "" || status == nil {
+		return errors.New("verify: code and status must not be empty")
+	}
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	t := *ver.codes.Load()
+	t.statuses = maps.Clone(t.statuses)
+	t.statuses[code] = status
+	ver.codes.Store(&t)
+	return nil
+}
+
+// Code returns the machine-readable code of fe: the one given by the
+// "code" tag of the field, else the one registered for its tag (or the
+// tag an alias expands to), else one derived from the tag in upper snake
+// case, e.g. required → FIELD_REQUIRED, eqField → FIELD_EQ_FIELD.
+func (ver *Verifier) Code(fe validator.FieldError) string {
+	if w, ok := fe.(*wrappedError); ok && w.code != "" {
+		return w.code
+	}
+	tags := ver.codes.Load().tags
+	if code, ok := tags[fe.Tag()]; ok {
+		return code
+	}
+	if code, ok := tags[fe.ActualTag()]; ok {
+		return code
+	}
+	return defaultCode(fe.Tag())
+}
+
+// tagCode returns the code of a violation of tag that has no field.
+func (ver *Verifier) tagCode(tag string) string {
+	if code, ok := ver.codes.Load().tags[tag]; ok {
+		return code
+	}
+	return defaultCode(tag)
+}
+
+func defaultCode(tag string) string {
+	var b strings.Builder
+	b.WriteString("FIELD_")
+	for i, r := range tag {
+		switch {
+		case 'a' <= r && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case 'A' <= r && r <= 'Z':
+			if i > 0 && 'a' <= tag[i-1] && tag[i-1] <= 'z' {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		case '0' <= r && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// status returns the goerr status of an error reporting fe.
+func (ver *Verifier) status(fe validator.FieldError) *goerr.Status {
+	if status, ok := ver.codes.Load().statuses[ver.Code(fe)]; ok {
+		return status
+	}
+	return goerr.StatusValidateParams()
+}
+
+// fieldCode returns the code a "code" tag spec gives to a violation of tag.
+func fieldCode(spec, tag string) string {
+	var fallback string
+	for part := range strings.SplitSeq(spec, ",") {
+		t, code, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			fallback = t
+		} else if t == tag {
+			return code
+		}
+	}
+	return fallback
+}
+
+// firstSorted returns the violation of errs whose field sorts first, the
+// one [Verifier.StructErr] reports.
+func firstSorted(errs validator.ValidationErrors) validator.FieldError {
+	var first validator.FieldError
+	var key string
+	for _, fe := range errs {
+		if k := trimTopStruct(fe.Namespace()); first == nil || k <= key {
+			first, key = fe, k
+		}
+	}
+	return first
+}
@@ -0,0 +1,60 @@
+package verify_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gtkit/goerr"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Signup struct {
+	Name  string   `json:"name" binding:"required,max=5" code:"required=NAME_MISSING,NAME_INVALID"`
+	Email string   `json:"email" binding:"required,email"`
+	Phone string   `json:"phone" binding:"omitempty,e164"`
+	Tags  []string `json:"tags" binding:"dive,alpha" code:"TAG_INVALID"`
+}
+
+func TestCodes(t *testing.T) {
+	v := verify.MustNew(verify.WithCodes(map[string]string{"e164": "PHONE_INVALID"}))
+	codes := func(s Signup) map[string]string {
+		out := map[string]string{}
+		for _, fv := range v.Errors(v.Struct(s)).Violations {
+			out[fv.Field] = fv.Code
+		}
+		return out
+	}
+
+	got := codes(Signup{Email: "bad", Phone: "123", Tags: []string{"ok", "n0"}})
+	want := map[string]string{"name": "NAME_MISSING", "email": "FIELD_EMAIL", "phone": "PHONE_INVALID", "tags[1]": "TAG_INVALID"}
+	for field, code := range want {
+		if got[field] != code {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if got := codes(Signup{Name: "toolong", Email: "a@b.com"}); got["name"] != "NAME_INVALID" {
+		t.Fatalf("expected the bare code for max, got %v", got)
+	}
+	if err := v.RegisterCode("email", "EMAIL_INVALID"); err != nil {
+		t.Fatal(err)
+	}
+	if got := codes(Signup{Name: "bob", Email: "bad"}); got["email"] != "EMAIL_INVALID" {
+		t.Fatalf("expected the registered code, got %v", got)
+	}
+	if err := v.RegisterCode("", "X"); err == nil {
+		t.Fatal("expected error for empty tag")
+	}
+}
+
+func TestCodes_Status(t *testing.T) {
+	v := verify.MustNew(verify.WithCodeStatuses(map[string]*goerr.Status{"NAME_MISSING": goerr.StatusConflict()}))
+
+	var item *goerr.Item
+	if err := v.StructErr(v.Struct(Signup{Email: "a@b.com"})); !errors.As(err, &item) || item.Code() != goerr.StatusConflict().Code() {
+		t.Fatalf("expected the conflict status, got %v", err)
+	}
+	if err := v.StructErr(v.Struct(Signup{Name: "bob"})); !errors.As(err, &item) || item.Code() != goerr.StatusValidateParams().Code() {
+		t.Fatalf("expected the default status, got %v", err)
+	}
+}
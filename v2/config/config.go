@@ -0,0 +1,156 @@
+// Package config decodes configuration files and environment variables
+// and validates them with a [verify.Verifier], reporting each violation at
+// its source:
+//
+//	var cfg ServerConfig
+//	if err := config.Load(v, "config.yaml", &cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+//	// config.yaml:12:5 server.port: port最小只能为1
+//	// config.yaml:20:7 databases[1].dsn: dsn为必填字段
+//
+// YAML, JSON and TOML files are decoded with their "yaml", "json" and
+// "toml" tags; positions point at the key of the offending value. Values
+// missing from the file are reported without a position. Messages name
+// fields through the Verifier's tag name func, the "json" tag by default.
+package config
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+// Violation is a [verify.FieldViolation] located in its source.
+type Violation struct {
+	Source string // file name or environment variable
+	Line   int    // 1-based; 0 if the value is not in the file
+	Column int    // 1-based
+	Path   string // key path in the file, e.g. "server.port"
+	verify.FieldViolation
+}
+
+// String formats v as "config.yaml:12:5 server.port: message", or as
+// "APP_PORT: message" for environment variables.
+func (v Violation) String() string {
+	switch {
+	case v.Line > 0:
+		return fmt.Sprintf("%s:%d:%d %s: %s", v.Source, v.Line, v.Column, v.Path, v.Message)
+	case v.Path != "":
+		return fmt.Sprintf("%s %s: %s", v.Source, v.Path, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", v.Source, v.Message)
+}
+
+// Error is returned when a decoded configuration fails validation. Its
+// message lists one violation per line in source order.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = v.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Load reads the file at path, decodes it into dst according to its
+// extension (.yaml, .yml, .json or .toml) and validates it with ver, or
+// with [verify.Default] if ver is nil.
+func Load(ver *verify.Verifier, path string, dst any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return Decode(ver, path, data, dst)
+}
+
+// Decode is like [Load] for data already read; name selects the format by
+// its extension and is used as the source of violations.
+func Decode(ver *verify.Verifier, name string, data []byte, dst any) error {
+	var (
+		tagKey    string
+		positions positions
+		err       error
+	)
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".yaml", ".yml":
+		tagKey = "yaml"
+		if err = yaml.Unmarshal(data, dst); err == nil {
+			positions, err = yamlPositions(data)
+		}
+	case ".json":
+		tagKey = "json"
+		if err = json.Unmarshal(data, dst); err == nil {
+			positions, err = jsonPositions(data)
+		}
+	case ".toml":
+		tagKey = "toml"
+		if err = toml.Unmarshal(data, dst); err == nil {
+			positions, err = tomlPositions(data)
+		}
+	default:
+		return fmt.Errorf("config: unsupported file extension %q", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", name, err)
+	}
+
+	return validate(ver, dst, func(fv verify.FieldViolation) Violation {
+		path := docPath(reflect.TypeOf(dst), fv.StructField, tagKey)
+		pos := positions.lookup(path)
+		return Violation{Source: name, Line: pos.line, Column: pos.column, Path: path, FieldViolation: fv}
+	})
+}
+
+// validate validates dst and locates its violations with locate.
+func validate(ver *verify.Verifier, dst any, locate func(verify.FieldViolation) Violation) error {
+	if ver == nil {
+		ver = verify.Default()
+	}
+	if ver == nil {
+		return errors.New("config: verify not initialized")
+	}
+	err := ver.StructCtx(context.Background(), dst)
+	if err == nil {
+		return nil
+	}
+	errs := ver.Errors(err)
+	if errs == nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	out := &Error{Violations: make([]Violation, 0, errs.Len())}
+	for _, fv := range errs.Violations {
+		out.Violations = append(out.Violations, locate(fv))
+	}
+	slices.SortStableFunc(out.Violations, func(a, b Violation) int {
+		// Violations without a position go last.
+		if (a.Line == 0) != (b.Line == 0) {
+			if a.Line == 0 {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			cmp.Compare(a.Path, b.Path),
+		)
+	})
+	return out
+}
@@ -0,0 +1,172 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/config"
+)
+
+type Database struct {
+	Name string `json:"name" yaml:"name" toml:"name" binding:"required"`
+	DSN  string `json:"dsn" yaml:"dsn" toml:"dsn" binding:"required,url"`
+}
+
+type ServerConfig struct {
+	Server struct {
+		Host string `json:"host" yaml:"host" toml:"host" binding:"required"`
+		Port int    `json:"port" yaml:"port" toml:"port" binding:"min=1,max=65535"`
+	} `json:"server" yaml:"server" toml:"server"`
+	Databases []Database `json:"databases" yaml:"databases" toml:"databases" binding:"dive"`
+}
+
+func TestDecode(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("zh"))
+	files := map[string]string{
+		"config.yaml": `server:
+  host: localhost
+  port: 0
+databases:
+  - name: main
+    dsn: postgres://localhost/main
+  - name: replica
+    dsn: not a url
+`,
+		"config.json": `{
+  "server": {
+    "host": "localhost",
+    "port": 0
+  },
+  "databases": [
+    {"name": "main", "dsn": "postgres://localhost/main"},
+    {"name": "replica", "dsn": "not a url"}
+  ]
+}`,
+		"config.toml": `[server]
+host = "localhost"
+port = 0
+
+[[databases]]
+name = "main"
+dsn = "postgres://localhost/main"
+
+[[databases]]
+name = "replica"
+dsn = "not a url"
+`,
+	}
+	want := map[string][]string{
+		"config.yaml": {
+			"config.yaml:3:3 server.port: port最小只能为1",
+			"config.yaml:8:5 databases[1].dsn: dsn必须是一个有效的URL",
+		},
+		"config.json": {
+			"config.json:4:5 server.port: port最小只能为1",
+			"config.json:8:25 databases[1].dsn: dsn必须是一个有效的URL",
+		},
+		"config.toml": {
+			"config.toml:3:1 server.port: port最小只能为1",
+			"config.toml:11:1 databases[1].dsn: dsn必须是一个有效的URL",
+		},
+	}
+	for name, data := range files {
+		var cfg ServerConfig
+		err := config.Decode(v, name, []byte(data), &cfg)
+		var cerr *config.Error
+		if !errors.As(err, &cerr) {
+			t.Fatalf("%s: expected *config.Error, got %v", name, err)
+		}
+		if got := strings.Split(cerr.Error(), "\n"); strings.Join(got, "\n") != strings.Join(want[name], "\n") {
+			t.Fatalf("%s: expected\n%s\ngot\n%s", name, strings.Join(want[name], "\n"), cerr)
+		}
+	}
+}
+
+func TestDecode_Missing(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	var cfg ServerConfig
+	err := config.Decode(v, "config.yaml", []byte("server:\n  port: 80\n"), &cfg)
+	if err == nil || err.Error() != "config.yaml server.host: host is a required field" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+type RateLimit struct {
+	Max int `json:"max" yaml:"max" binding:"max=100"`
+}
+
+type LimitsConfig struct {
+	Limits map[string]RateLimit `json:"limits" yaml:"limits" binding:"dive"`
+}
+
+func TestDecode_MapValues(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	var cfg LimitsConfig
+	err := config.Decode(v, "config.yaml", []byte("limits:\n  api:\n    max: 500\n"), &cfg)
+	if err == nil || err.Error() != "config.yaml:3:5 limits.api.max: max must be 100 or less" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	v := verify.MustNew()
+	var cfg ServerConfig
+	if err := config.Decode(v, "config.ini", nil, &cfg); err == nil {
+		t.Fatal("expected unsupported extension error")
+	}
+	err := config.Decode(v, "config.json", []byte(`{"server": {"port": "x"}}`), &cfg)
+	var cerr *config.Error
+	if err == nil || errors.As(err, &cerr) {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	data := "server:\n  host: localhost\n  port: 8080\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var cfg ServerConfig
+	if err := config.Load(verify.MustNew(), path, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 8080 {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+}
+
+type EnvConfig struct {
+	Port    int           `json:"port" env:"PORT" binding:"required,min=1,max=65535"`
+	Timeout time.Duration `json:"timeout" env:"TIMEOUT" binding:"required"`
+	DB      struct {
+		DSN string `json:"dsn" env:"DSN" binding:"required,url"`
+	} `json:"db" env:"DB"`
+}
+
+func TestLoadEnv(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("zh"))
+	t.Setenv("APP_PORT", "70000")
+	t.Setenv("APP_TIMEOUT", "5s")
+	t.Setenv("APP_DB_DSN", "not a url")
+
+	var cfg EnvConfig
+	err := config.LoadEnv(v, "APP_", &cfg)
+	want := "APP_DB_DSN: dsn必须是一个有效的URL\nAPP_PORT: port必须小于或等于65,535"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected\n%s\ngot\n%v", want, err)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Fatalf("unexpected timeout %v", cfg.Timeout)
+	}
+
+	t.Setenv("APP_PORT", "eighty")
+	if err := config.LoadEnv(v, "APP_", &cfg); err == nil || !strings.Contains(err.Error(), "APP_PORT") {
+		t.Fatalf("expected parse error naming APP_PORT, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/internal/env"
+)
+
+// LoadEnv sets the fields of the struct pointed to by dst from environment
+// variables named by their "env" tag after prefix, and validates it with
+// ver, or with [verify.Default] if ver is nil. Violations are reported by
+// variable name:
+//
+//	type ServerConfig struct {
+//	    Port int      `env:"PORT" binding:"required,min=1,max=65535"`
+//	    DB   DBConfig `env:"DB"` // DB_DSN, DB_POOL
+//	}
+//
+//	err := config.LoadEnv(v, "APP_", &cfg)
+//	// APP_PORT: Port为必填字段
+//
+// A struct field with an "env" tag adds NAME_ to the prefix of its fields;
+// without one its fields share the prefix. The "envDefault" and
+// "envSeparator" tags and the supported types are those of
+// [verify.Verifier.BindEnv].
+func LoadEnv(ver *verify.Verifier, prefix string, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: LoadEnv requires a pointer to a struct, got %T", dst)
+	}
+	d := env.Decode(rv.Elem(), prefix)
+	if len(d.Failures) > 0 {
+		f := d.Failures[0]
+		return fmt.Errorf("config: %s: %w", f.Name, f.Err)
+	}
+	return validate(ver, dst, func(fv verify.FieldViolation) Violation {
+		source, ok := d.Vars[fv.StructField]
+		if !ok {
+			source = fv.Field
+		}
+		return Violation{Source: source, FieldViolation: fv}
+	})
+}
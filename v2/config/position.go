@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+type position struct {
+	line, column int
+}
+
+// positions maps key paths such as "servers[0].port" to the position of
+// their key in the document.
+type positions map[string]position
+
+// lookup returns the position of path, matching keys case-insensitively
+// like the decoders do when no tag names the field.
+func (p positions) lookup(path string) position {
+	if pos, ok := p[path]; ok {
+		return pos
+	}
+	for key, pos := range p {
+		if strings.EqualFold(key, path) {
+			return pos
+		}
+	}
+	return position{}
+}
+
+func (p positions) add(path string, pos position) {
+	if _, ok := p[path]; !ok {
+		p[path] = pos
+	}
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func indexPath(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+// ---------- YAML ----------
+
+func yamlPositions(data []byte) (positions, error) {
+	f, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	p := positions{}
+	for _, doc := range f.Docs {
+		p.walkYAML(doc.Body, "")
+	}
+	return p, nil
+}
+
+func (p positions) walkYAML(n ast.Node, path string) {
+	switch n := n.(type) {
+	case *ast.MappingNode:
+		for _, mv := range n.Values {
+			p.walkYAML(mv, path)
+		}
+	case *ast.MappingValueNode:
+		key := n.Key.String()
+		if s, ok := n.Key.(ast.ScalarNode); ok {
+			key = fmt.Sprint(s.GetValue())
+		}
+		child := joinPath(path, key)
+		p.add(child, yamlPosition(n.Key))
+		p.walkYAML(n.Value, child)
+	case *ast.SequenceNode:
+		for i, v := range n.Values {
+			child := indexPath(path, i)
+			p.add(child, yamlPosition(v))
+			p.walkYAML(v, child)
+		}
+	case *ast.AnchorNode:
+		p.walkYAML(n.Value, path)
+	case *ast.TagNode:
+		p.walkYAML(n.Value, path)
+	}
+}
+
+func yamlPosition(n ast.Node) position {
+	if tk := n.GetToken(); tk != nil && tk.Position != nil {
+		return position{tk.Position.Line, tk.Position.Column}
+	}
+	return position{}
+}
+
+// ---------- JSON ----------
+
+func jsonPositions(data []byte) (positions, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	p := positions{}
+	at := func() position {
+		// InputOffset is the end of the previous token; skip to the next.
+		off := int(dec.InputOffset())
+		for off < len(data) && strings.IndexByte(" \t\r\n,:", data[off]) >= 0 {
+			off++
+		}
+		return offsetPosition(data, off)
+	}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				pos := at()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child := joinPath(path, fmt.Sprint(key))
+				p.add(child, pos)
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				child := indexPath(path, i)
+				p.add(child, at())
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	return p, walk("")
+}
+
+// offsetPosition converts a byte offset into a line and a column counted
+// in characters.
+func offsetPosition(data []byte, off int) position {
+	lead := data[:off]
+	line := bytes.Count(lead, []byte("\n")) + 1
+	start := bytes.LastIndexByte(lead, '\n') + 1
+	return position{line, utf8.RuneCount(lead[start:]) + 1}
+}
+
+// ---------- TOML ----------
+
+func tomlPositions(data []byte) (positions, error) {
+	var tp unstable.Parser
+	tp.Reset(data)
+	p := positions{}
+	arrays := map[string]int{} // array table path → number of elements
+	at := func(n *unstable.Node) position {
+		return offsetPosition(data, int(n.Raw.Offset))
+	}
+	// resolve appends keys to parent, descending into the last element of
+	// array tables.
+	resolve := func(parent string, keys []*unstable.Node) string {
+		path := parent
+		for _, k := range keys {
+			path = joinPath(path, string(k.Data))
+			p.add(path, at(k))
+			if n, ok := arrays[path]; ok {
+				path = indexPath(path, n-1)
+			}
+		}
+		return path
+	}
+
+	var walkValue func(v *unstable.Node, path string)
+	walkValue = func(v *unstable.Node, path string) {
+		switch v.Kind {
+		case unstable.Array:
+			i := 0
+			for it := v.Children(); it.Next(); {
+				if el := it.Node(); el.Kind != unstable.Comment {
+					child := indexPath(path, i)
+					p.add(child, at(el))
+					walkValue(el, child)
+					i++
+				}
+			}
+		case unstable.InlineTable:
+			for it := v.Children(); it.Next(); {
+				kv := it.Node()
+				walkValue(kv.Value(), resolve(path, keyNodes(kv)))
+			}
+		}
+	}
+
+	table := ""
+	for tp.NextExpression() {
+		e := tp.Expression()
+		switch e.Kind {
+		case unstable.Table:
+			table = resolve("", keyNodes(e))
+		case unstable.ArrayTable:
+			keys := keyNodes(e)
+			base := joinPath(resolve("", keys[:len(keys)-1]), string(keys[len(keys)-1].Data))
+			arrays[base]++
+			table = indexPath(base, arrays[base]-1)
+			p.add(base, at(keys[len(keys)-1]))
+			p.add(table, at(keys[len(keys)-1]))
+		case unstable.KeyValue:
+			walkValue(e.Value(), resolve(table, keyNodes(e)))
+		}
+	}
+	return p, tp.Error()
+}
+
+func keyNodes(n *unstable.Node) []*unstable.Node {
+	var keys []*unstable.Node
+	for it := n.Key(); it.Next(); {
+		keys = append(keys, it.Node())
+	}
+	return keys
+}
+
+// ---------- Paths ----------
+
+// docPath converts a Go field namespace such as "Servers[0].Port" into the
+// key path of the document, "servers[0].port", using the names of the
+// tagKey struct tag. Map keys become path keys: "Limits[api].Max" is
+// "limits.api.max". Embedded structs without a name are inlined.
+func docPath(t reflect.Type, structNs, tagKey string) string {
+	var path string
+	for seg := range strings.SplitSeq(structNs, ".") {
+		name, index, _ := strings.Cut(seg, "[")
+		t = deref(t)
+		if t.Kind() == reflect.Struct {
+			if fld, ok := t.FieldByName(name); ok {
+				t = fld.Type
+				if key := tagName(fld, tagKey); key != "" {
+					path = joinPath(path, key)
+				}
+			} else {
+				path = joinPath(path, name)
+			}
+		}
+		for index != "" {
+			key, rest, _ := strings.Cut(index, "]")
+			index = strings.TrimPrefix(rest, "[")
+			switch t = deref(t); t.Kind() {
+			case reflect.Map:
+				path = joinPath(path, key)
+				t = t.Elem()
+			case reflect.Slice, reflect.Array:
+				path += "[" + key + "]"
+				t = t.Elem()
+			default:
+				path += "[" + key + "]"
+			}
+		}
+	}
+	return path
+}
+
+// tagName returns the document key of fld, or "" for an inlined embedded
+// struct.
+func tagName(fld reflect.StructField, tagKey string) string {
+	tag := fld.Tag.Get(tagKey)
+	name, opts, _ := strings.Cut(tag, ",")
+	if name != "" && name != "-" {
+		return name
+	}
+	if fld.Anonymous && (opts == "inline" || deref(fld.Type).Kind() == reflect.Struct) {
+		return ""
+	}
+	return fld.Name
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
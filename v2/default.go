@@ -2,10 +2,13 @@ package verify
 
 import (
 	"context"
+	"io/fs"
+	"net/url"
 	"sync"
 
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/gtkit/goerr"
 )
 
 var (
@@ -73,6 +76,16 @@ func Map(m map[string]any, rules map[string]any) map[string]any {
 func MapCtx(ctx context.Context, m map[string]any, rules map[string]any) map[string]any {
 	return mustDefault().MapCtx(ctx, m, rules)
 }
+func Values(values url.Values, rules map[string]string) (map[string]any, *Errors) {
+	return mustDefault().Values(values, rules)
+}
+func ValuesCtx(ctx context.Context, values url.Values, rules map[string]string) (map[string]any, *Errors) {
+	return mustDefault().ValuesCtx(ctx, values, rules)
+}
+func Warnings(s any) []FieldViolation { return mustDefault().Warnings(s) }
+func WarningsCtx(ctx context.Context, s any) []FieldViolation {
+	return mustDefault().WarningsCtx(ctx, s)
+}
 
 // ---------- Error helpers ----------
 
@@ -92,9 +105,28 @@ func SelfRegisterTranslation(method, info string, fn validator.Func) error {
 func AddValidationTranslation(method, info string) error {
 	return mustDefault().AddValidationTranslation(method, info)
 }
+func SelfRegisterPluralTranslation(method string, messages map[string]Plural, fn validator.Func) error {
+	return mustDefault().SelfRegisterPluralTranslation(method, messages, fn)
+}
+func AddPluralTranslation(method string, messages map[string]Plural) error {
+	return mustDefault().AddPluralTranslation(method, messages)
+}
 func RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
 	mustDefault().RegisterStructValidation(fn, types...)
 }
+func RegisterCheck(tag string, fn CheckFunc) error { return mustDefault().RegisterCheck(tag, fn) }
+func RegisterPreset(name, tags string, messages map[string]string) error {
+	return mustDefault().RegisterPreset(name, tags, messages)
+}
+func RegisterPasswordPolicy(p PasswordPolicy) error { return mustDefault().RegisterPasswordPolicy(p) }
+func RegisterCode(tag, code string) error           { return mustDefault().RegisterCode(tag, code) }
+func RegisterRedactor(name string, fn RedactFunc) error {
+	return mustDefault().RegisterRedactor(name, fn)
+}
+func RegisterCodeStatus(code string, status *goerr.Status) error {
+	return mustDefault().RegisterCodeStatus(code, status)
+}
+func LoadMessages(fsys fs.FS, pattern string) error { return mustDefault().LoadMessages(fsys, pattern) }
 
 // ---------- Accessors ----------
 
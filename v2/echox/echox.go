@@ -0,0 +1,75 @@
+// Package echox installs a [verify.Verifier] as the validator of an Echo
+// server and renders bind and validation errors.
+//
+//	v := verify.MustNew(verify.WithLocale("zh"))
+//	e := echo.New()
+//	e.Validator = echox.NewValidator(v)
+//
+//	e.POST("/signup", func(c echo.Context) error {
+//	    params, err := echox.Bind[SignUpParams](c)
+//	    if err != nil {
+//	        return echox.Error(c, err)
+//	    }
+//	    return c.JSON(http.StatusOK, params)
+//	})
+package echox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/labstack/echo/v4"
+)
+
+// Validator implements echo.Validator with a [verify.Verifier].
+type Validator struct {
+	ver *verify.Verifier
+}
+
+// NewValidator returns an echo.Validator backed by ver.
+func NewValidator(ver *verify.Verifier) *Validator {
+	return &Validator{ver: ver}
+}
+
+// Validate validates i with the "binding" tags and async checks.
+func (v *Validator) Validate(i any) error {
+	return v.ver.Struct(i)
+}
+
+// Verifier returns the underlying Verifier.
+func (v *Validator) Verifier() *verify.Verifier { return v.ver }
+
+// Bind binds the request into a new T with c.Bind and validates it with
+// the server's validator.
+func Bind[T any](c echo.Context) (T, error) {
+	var obj T
+	if err := c.Bind(&obj); err != nil {
+		return obj, err
+	}
+	return obj, c.Validate(&obj)
+}
+
+// Error writes the [verify.ErrorResponse] for err, localized by the
+// Verifier installed with [NewValidator]. Bind and validation failures are
+// answered with 400; other Echo errors keep their status code.
+func Error(c echo.Context, err error) error {
+	if he, ok := errors.AsType[*echo.HTTPError](err); ok && he.Internal == nil {
+		return c.JSON(he.Code, verify.ErrorResponse{Message: fmt.Sprint(he.Message)})
+	}
+	ver := verifier(c)
+	if ver == nil {
+		return c.JSON(http.StatusBadRequest, verify.ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusBadRequest, ver.Response(err))
+}
+
+// verifier returns the Verifier of the server's validator, falling back to
+// the package-level default, which may be nil.
+func verifier(c echo.Context) *verify.Verifier {
+	if v, ok := c.Echo().Validator.(*Validator); ok {
+		return v.ver
+	}
+	return verify.Default()
+}
@@ -0,0 +1,60 @@
+package echox_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/echox"
+	"github.com/labstack/echo/v4"
+)
+
+type SignUpParams struct {
+	Name  string `json:"name" binding:"required,min=2"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+func TestBind(t *testing.T) {
+	e := echo.New()
+	e.Validator = echox.NewValidator(verify.MustNew(verify.WithLocale("zh")))
+	e.POST("/signup", func(c echo.Context) error {
+		params, err := echox.Bind[SignUpParams](c)
+		if err != nil {
+			return echox.Error(c, err)
+		}
+		return c.JSON(http.StatusOK, params)
+	})
+
+	cases := []struct {
+		body, ctype, field, message string
+		code                        int
+	}{
+		{`{"name":"alice","email":"a@b.com"}`, echo.MIMEApplicationJSON, "", "", 200},
+		{`{"name":"a","email":"a@b.com"}`, echo.MIMEApplicationJSON, "name", "name长度必须至少为2个字符", 400},
+		{`{"name":1}`, echo.MIMEApplicationJSON, "name", "name类型不正确", 400},
+		{`{"name":`, echo.MIMEApplicationJSON, "", "请求体格式错误", 400},
+		{`name`, "application/x-unknown", "", "Unsupported Media Type", 415},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tc.body))
+		req.Header.Set(echo.HeaderContentType, tc.ctype)
+		e.ServeHTTP(w, req)
+		if w.Code != tc.code {
+			t.Fatalf("%s: expected %d, got %d %s", tc.body, tc.code, w.Code, w.Body)
+		}
+		if tc.code == 200 {
+			continue
+		}
+		var resp verify.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Message != tc.message || tc.field != "" && resp.Errors[0].Field != tc.field {
+			t.Fatalf("%s: unexpected response %+v", tc.body, resp)
+		}
+	}
+}
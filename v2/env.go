@@ -0,0 +1,103 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gtkit/verify/v2/internal/env"
+)
+
+// envTag is the struct tag naming the environment variable of a field, and
+// the tag of the violations reported for values that cannot be parsed.
+const envTag = "env"
+
+var envTexts = map[string]string{
+	"zh": "{0}的值格式不正确",
+	"en": "{0} has an invalid value",
+}
+
+func envText(locale string) string {
+	if text, ok := envTexts[locale]; ok {
+		return text
+	}
+	return envTexts["en"]
+}
+
+// FromEnv returns a T populated from environment variables and validated
+// with the package-level default Verifier, see [Verifier.BindEnv].
+//
+//	type Config struct {
+//	    Port    int           `json:"port" env:"PORT" envDefault:"8080" binding:"min=1,max=65535"`
+//	    Timeout time.Duration `json:"timeout" env:"TIMEOUT" envDefault:"5s"`
+//	    Hosts   []string      `json:"hosts" env:"HOSTS" binding:"required,dive,hostname"`
+//	    DB      DBConfig      `json:"db" env:"DB"` // APP_DB_DSN, APP_DB_POOL
+//	}
+//
+//	cfg, errs := verify.FromEnv[Config]("APP_")
+//	if errs != nil {
+//	    log.Fatal(errs.Map()) // map[APP_PORT:port必须小于或等于65,535]
+//	}
+func FromEnv[T any](prefix string) (T, *Errors) {
+	var value T
+	errs := mustDefault().BindEnv(prefix, &value)
+	return value, errs
+}
+
+// BindEnv sets the fields of the struct pointed to by dst from environment
+// variables and validates it. errs is nil if the result is valid;
+// otherwise its violations are keyed by variable name, with the field's
+// translated message, or wrap the error that prevented validation.
+//
+// A field reads the variable prefix + its "env" tag, or its "envDefault"
+// tag when the variable is unset. A struct field with an "env" tag adds
+// NAME_ to the prefix of its fields; without one its fields share the
+// prefix; a nil pointer to a struct stays nil unless one of its fields is
+// set. Supported are strings, booleans, numbers, time.Duration,
+// encoding.TextUnmarshaler, pointers to them and slices of them, split on
+// "," or the "envSeparator" tag. A value that cannot be parsed is a
+// violation with the tag "env".
+func (ver *Verifier) BindEnv(prefix string, dst any) *Errors {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return &Errors{cause: fmt.Errorf("verify: BindEnv requires a pointer to a struct, got %T", dst)}
+	}
+	d := env.Decode(rv.Elem(), prefix)
+
+	out := &Errors{}
+	for _, f := range d.Failures {
+		value := ver.Redact(f.Field, f.Raw)
+		param, _ := value.(string)
+		msg, err := ver.trans.T(envTag, f.Name)
+		if err != nil {
+			msg = fmt.Sprintf("verify: %s: %v", f.Name, f.Err)
+		}
+		out.Violations = append(out.Violations, FieldViolation{
+			Field:       f.Name,
+			StructField: f.StructNs,
+			Tag:         envTag,
+			Code:        ver.tagCode(envTag),
+			Param:       param,
+			Value:       value,
+			Message:     msg,
+		})
+	}
+	err := ver.StructCtx(context.Background(), dst)
+	if errs := ver.Errors(err); errs != nil {
+		for _, fv := range errs.Violations {
+			if d.Failed[fv.StructField] {
+				continue // already reported as unparsable
+			}
+			if name, ok := d.VarOf(fv.StructField); ok {
+				fv.Field = name
+			}
+			out.Violations = append(out.Violations, fv)
+		}
+	} else if err != nil {
+		return &Errors{cause: err}
+	}
+	if len(out.Violations) == 0 {
+		return nil
+	}
+	return out
+}
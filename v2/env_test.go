@@ -0,0 +1,132 @@
+package verify_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type DBEnv struct {
+	DSN  string `json:"dsn" env:"DSN" binding:"required,url"`
+	Pool int    `json:"pool" env:"POOL" envDefault:"10" binding:"min=1"`
+}
+
+type AppEnv struct {
+	Port    int           `json:"port" env:"PORT" envDefault:"8080" binding:"min=1,max=65535"`
+	Timeout time.Duration `json:"timeout" env:"TIMEOUT" envDefault:"5s"`
+	Hosts   []string      `json:"hosts" env:"HOSTS" binding:"required,dive,hostname"`
+	Ports   []int         `json:"ports" env:"PORTS" envSeparator:";"`
+	Addr    netip.Addr    `json:"addr" env:"ADDR"`
+	Debug   *bool         `json:"debug" env:"DEBUG"`
+	DB      DBEnv         `json:"db" env:"DB"`
+	Ignored string        `env:"-"`
+}
+
+func TestBindEnv(t *testing.T) {
+	v := newVerifier(t)
+	t.Setenv("APP_HOSTS", "api.example.com, db.example.com")
+	t.Setenv("APP_PORTS", "80;443")
+	t.Setenv("APP_ADDR", "127.0.0.1")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_DB_DSN", "postgres://localhost/app")
+
+	var cfg AppEnv
+	if errs := v.BindEnv("APP_", &cfg); errs != nil {
+		t.Fatalf("unexpected errors %v", errs.Map())
+	}
+	if cfg.Port != 8080 || cfg.Timeout != 5*time.Second || len(cfg.Hosts) != 2 || cfg.Hosts[1] != "db.example.com" ||
+		len(cfg.Ports) != 2 || cfg.Ports[1] != 443 || cfg.Addr.String() != "127.0.0.1" || cfg.Debug == nil || !*cfg.Debug ||
+		cfg.DB.Pool != 10 {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+}
+
+func TestBindEnv_Violations(t *testing.T) {
+	v := newVerifier(t)
+	t.Setenv("APP_PORT", "70000")
+	t.Setenv("APP_TIMEOUT", "soon")
+	t.Setenv("APP_HOSTS", "")
+	t.Setenv("APP_PORTS", "80;http")
+	t.Setenv("APP_DB_POOL", "0")
+
+	var cfg AppEnv
+	errs := v.BindEnv("APP_", &cfg)
+	want := map[string]string{
+		"APP_PORT":    "port必须小于或等于65,535",
+		"APP_TIMEOUT": "APP_TIMEOUT的值格式不正确",
+		"APP_HOSTS":   "hosts为必填字段",
+		"APP_PORTS":   "APP_PORTS的值格式不正确",
+		"APP_DB_DSN":  "dsn为必填字段",
+		"APP_DB_POOL": "pool最小只能为1",
+	}
+	got := errs.Map()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, msg := range want {
+		if got[k] != msg {
+			t.Fatalf("expected %s=%q, got %v", k, msg, got)
+		}
+	}
+}
+
+func TestBindEnv_Invalid(t *testing.T) {
+	v := newVerifier(t)
+	var cfg AppEnv
+	if errs := v.BindEnv("APP_", cfg); errs == nil || errs.Len() != 0 || errs.Unwrap() == nil {
+		t.Fatalf("expected an argument error, got %v", errs)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	if err := verify.Init(verify.WithLocale("zh")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SVC_HOSTS", "localhost")
+	t.Setenv("SVC_DB_DSN", "postgres://localhost/app")
+	cfg, errs := verify.FromEnv[AppEnv]("SVC_")
+	if errs != nil {
+		t.Fatalf("unexpected errors %v", errs.Map())
+	}
+	if cfg.Port != 8080 || cfg.Hosts[0] != "localhost" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+}
+
+type TLSEnv struct {
+	Cert string `json:"cert" env:"CERT" binding:"required"`
+}
+
+type ServerEnv struct {
+	Port int     `json:"port" env:"PORT" envDefault:"8443"`
+	TLS  *TLSEnv `json:"tls" env:"TLS" binding:"omitempty"`
+}
+
+func TestBindEnv_OptionalSection(t *testing.T) {
+	v := newVerifier(t)
+	var cfg ServerEnv
+	if errs := v.BindEnv("SRV_", &cfg); errs != nil || cfg.TLS != nil {
+		t.Fatalf("expected no TLS section, got %+v and %v", cfg.TLS, errs.Map())
+	}
+
+	t.Setenv("SRV_TLS_CERT", "/etc/tls/cert.pem")
+	if errs := v.BindEnv("SRV_", &cfg); errs != nil || cfg.TLS == nil || cfg.TLS.Cert != "/etc/tls/cert.pem" {
+		t.Fatalf("expected the TLS section, got %+v and %v", cfg.TLS, errs.Map())
+	}
+}
+
+type NodeEnv struct {
+	Name string   `json:"name" env:"NAME" binding:"required"`
+	Next *NodeEnv `json:"next" env:"NEXT"`
+}
+
+func TestBindEnv_RecursiveType(t *testing.T) {
+	v := newVerifier(t)
+	t.Setenv("NODE_NAME", "head")
+	var n NodeEnv
+	if errs := v.BindEnv("NODE_", &n); errs != nil || n.Name != "head" || n.Next != nil {
+		t.Fatalf("unexpected node %+v, errors %v", n, errs.Map())
+	}
+}
@@ -3,14 +3,20 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"slices"
+	"strings"
 
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/gtkit/goerr"
 )
 
-// FieldErr translates a field validation error into a human-readable error.
-// field is the display name prepended to the message.
+// FieldErr translates a field validation error into a human-readable error
+// built by the error factory, see [WithErrorFactory]. field is the display
+// name prepended to the message. Warnings are ignored. The goerr status is
+// the one registered for the code of the violation, see
+// [Verifier.RegisterCodeStatus].
 //
 //	err := v.Field(p, "required,numeric")
 //	if err != nil {
@@ -20,17 +26,13 @@ func (ver *Verifier) FieldErr(field string, err error) error {
 	if err == nil {
 		return nil
 	}
-	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
-	if !ok {
-		return goerr.New(err, goerr.StatusValidateParams(), "非ValidationErrors类型错误")
-	}
-	if msg, ok := firstSortedMessage(RemoveTopStruct(valErrs.Translate(ver.trans))); ok {
-		return goerr.New(fmt.Errorf("%s %s", field, msg), goerr.StatusValidateParams(), "字段验证错误")
-	}
-	return nil
+	return ver.reportError(FieldErrorKind, field+" ", err)
 }
 
-// StructErr translates a struct validation error into a human-readable error.
+// StructErr translates a struct validation error into a human-readable error
+// built by the error factory. Warnings are ignored. The goerr status is the
+// one registered for the code of the reported violation,
+// goerr.StatusValidateParams() by default.
 //
 //	err := v.Struct(params)
 //	if err != nil {
@@ -40,18 +42,29 @@ func (ver *Verifier) StructErr(err error) error {
 	if err == nil {
 		return nil
 	}
+	return ver.reportError(StructErrorKind, "", err)
+}
+
+// reportError builds the error of FieldErr or StructErr with the error
+// factory, see [WithErrorFactory].
+func (ver *Verifier) reportError(kind ErrorKind, prefix string, err error) error {
+	r := ErrorReport{Kind: kind, Locale: ver.locale, Status: goerr.StatusValidateParams(), Cause: err}
 	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
 	if !ok {
-		return goerr.New(err, goerr.StatusValidateParams(), "非ValidationErrors类型错误")
+		r.Invalid = true
+		return ver.errorFactory(r)
 	}
-	if msg, ok := firstSortedMessage(RemoveTopStruct(valErrs.Translate(ver.trans))); ok {
-		return goerr.New(goerr.Err(msg), goerr.StatusValidateParams(), "结构验证错误")
+	fe := firstSorted(valErrs)
+	if fe == nil {
+		return nil
 	}
-	return nil
+	fv := ver.violation(fe)
+	r.Message, r.Violation, r.Status = prefix+fv.Message, &fv, ver.status(fe)
+	return ver.errorFactory(r)
 }
 
-// MapErr translates a map validation result into a human-readable error.
-// result is the return value of [Verifier.Map].
+// MapErr translates a map validation result into a human-readable error
+// built by the error factory. result is the return value of [Verifier.Map].
 //
 //	result := v.Map(data, rules)
 //	if len(result) > 0 {
@@ -62,13 +75,23 @@ func (ver *Verifier) MapErr(result map[string]any) error {
 		return nil
 	}
 	msgs := ver.AllMapErrors(result)
-	if key := firstSortedKey(msgs); key != "" {
-		return goerr.New(fmt.Errorf("%s %s", key, msgs[key]), goerr.StatusValidateParams(), "映射验证错误")
+	key := firstSortedKey(msgs)
+	if key == "" {
+		return nil
+	}
+	r := ErrorReport{Kind: MapErrorKind, Locale: ver.locale, Message: key + " " + msgs[key], Status: goerr.StatusValidateParams()}
+	if valErrs, ok := result[key].(validator.ValidationErrors); ok {
+		if fe := firstSorted(valErrs); fe != nil {
+			fv := ver.violation(fe)
+			fv.Field = key
+			r.Violation, r.Status = &fv, ver.status(fe)
+		}
 	}
-	return nil
+	return ver.errorFactory(r)
 }
 
-// AllFieldErrors translates all field validation errors.
+// AllFieldErrors translates all field validation errors, without the
+// warnings of "warn" tags; [Verifier.Errors] has both.
 // Returns a map of field name → translated message, or nil if err is nil.
 //
 //	err := v.Struct(params)
@@ -85,7 +108,7 @@ func (ver *Verifier) AllFieldErrors(err error) map[string]string {
 	if !ok {
 		return nil
 	}
-	return RemoveTopStruct(valErrs.Translate(ver.trans))
+	return RemoveTopStruct(ver.translateAll(valErrs))
 }
 
 // AllMapErrors translates all map validation errors.
@@ -101,13 +124,132 @@ func (ver *Verifier) AllMapErrors(result map[string]any) map[string]string {
 			out[key] = fmt.Sprint(val)
 			continue
 		}
-		if msg, ok := firstSortedMessage(valErrs.Translate(ver.trans)); ok {
+		if msg, ok := firstSortedMessage(ver.translateAll(valErrs)); ok {
 			out[key] = msg
 		}
 	}
 	return out
 }
 
+// FieldViolation is a single translated validation failure.
+type FieldViolation struct {
+	Field       string `json:"field"` // namespace without the top struct, e.g. "items[0].name"
+	StructField string `json:"-"`     // Go field namespace without the top struct
+	Tag         string `json:"tag"`
+	Code        string `json:"code"` // machine-readable, see [Verifier.Code]
+	Param       string `json:"param,omitempty"`
+	Value       any    `json:"-"`
+	Message     string `json:"message"`
+	Warning     bool   `json:"warning,omitempty"` // reported by a "warn" tag
+}
+
+// Errors is an ordered set of translated violations. It implements error;
+// Error returns the message of the first field in sorted order, like
+// [Verifier.StructErr].
+type Errors struct {
+	Violations []FieldViolation
+	Warnings   []FieldViolation // violations of "warn" tags, which do not fail validation
+
+	cause error // failure to validate, set by [Check] when there are no violations
+}
+
+func (e *Errors) Error() string {
+	if e == nil {
+		return ""
+	}
+	if len(e.Violations) == 0 {
+		if e.cause != nil {
+			return e.cause.Error()
+		}
+		return ""
+	}
+	first := e.Violations[0]
+	for _, fv := range e.Violations[1:] {
+		if fv.Field < first.Field {
+			first = fv
+		}
+	}
+	return first.Message
+}
+
+// Unwrap returns the error that prevented validation, if any, such as an
+// invalid argument or a canceled context.
+func (e *Errors) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+// Len returns the number of violations.
+func (e *Errors) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.Violations)
+}
+
+// Map returns field → message, like [Verifier.AllFieldErrors], including
+// the warnings.
+func (e *Errors) Map() map[string]string {
+	if e == nil {
+		return nil
+	}
+	out := make(map[string]string, len(e.Violations)+len(e.Warnings))
+	for _, fv := range e.Warnings {
+		out[fv.Field] = fv.Message
+	}
+	for _, fv := range e.Violations {
+		out[fv.Field] = fv.Message
+	}
+	return out
+}
+
+// Errors translates a validation error into [Errors], with the warnings of
+// "warn" tags flagged and kept apart from the violations.
+// Returns nil if err is nil or not a [validator.ValidationErrors].
+//
+//	if errs := v.Errors(v.Struct(params)); errs != nil {
+//	    c.JSON(http.StatusBadRequest, errs.Violations)
+//	}
+func (ver *Verifier) Errors(err error) *Errors {
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if !ok || len(valErrs) == 0 {
+		return nil
+	}
+	out := &Errors{Violations: make([]FieldViolation, 0, len(valErrs))}
+	for _, fe := range valErrs {
+		out.Violations = append(out.Violations, ver.violation(fe))
+		if w, ok := fe.(*wrappedError); ok {
+			for _, warning := range w.warnings {
+				out.Warnings = append(out.Warnings, ver.violation(warning))
+			}
+		}
+	}
+	return out
+}
+
+// violation translates fe into a [FieldViolation].
+func (ver *Verifier) violation(fe validator.FieldError) FieldViolation {
+	return FieldViolation{
+		Field:       trimTopStruct(fe.Namespace()),
+		StructField: trimTopStruct(fe.StructNamespace()),
+		Tag:         fe.Tag(),
+		Code:        ver.Code(fe),
+		Param:       fe.Param(),
+		Value:       fe.Value(),
+		Message:     ver.translate(fe),
+		Warning:     IsWarning(fe),
+	}
+}
+
+func trimTopStruct(ns string) string {
+	if _, after, ok := strings.Cut(ns, "."); ok {
+		return after
+	}
+	return ns
+}
+
 func firstSortedKey(fields map[string]string) string {
 	if len(fields) == 0 {
 		return ""
@@ -119,3 +261,156 @@ func firstSortedKey(fields map[string]string) string {
 	slices.Sort(keys)
 	return keys[0]
 }
+
+// fieldError is a [validator.FieldError] reported by verify itself rather
+// than by validator, so it merges into [validator.ValidationErrors].
+type fieldError struct {
+	tag         string
+	ns          string
+	structNs    string
+	field       string
+	structField string
+	value       any
+	param       string
+	kind        reflect.Kind
+	typ         reflect.Type
+	msg         string // used when tag has no translation
+	fixed       bool   // msg is used regardless of translations
+}
+
+func newFieldError(n *fieldNode, tag, param string, value any, msg string) *fieldError {
+	return &fieldError{
+		tag:         tag,
+		ns:          n.ns,
+		structNs:    n.structNs,
+		field:       n.name,
+		structField: n.field.Name,
+		value:       value,
+		param:       param,
+		kind:        n.value.Kind(),
+		typ:         n.field.Type,
+		msg:         msg,
+	}
+}
+
+func (fe *fieldError) Tag() string             { return fe.tag }
+func (fe *fieldError) ActualTag() string       { return fe.tag }
+func (fe *fieldError) Namespace() string       { return fe.ns }
+func (fe *fieldError) StructNamespace() string { return fe.structNs }
+func (fe *fieldError) Field() string           { return fe.field }
+func (fe *fieldError) StructField() string     { return fe.structField }
+func (fe *fieldError) Value() any              { return fe.value }
+func (fe *fieldError) Param() string           { return fe.param }
+func (fe *fieldError) Kind() reflect.Kind      { return fe.kind }
+func (fe *fieldError) Type() reflect.Type      { return fe.typ }
+
+// Translate uses the translation registered for the tag, falling back to
+// the message the error was reported with. A fixed message always wins.
+func (fe *fieldError) Translate(trans ut.Translator) string {
+	if fe.fixed {
+		return fe.msg
+	}
+	if msg, err := trans.T(fe.tag, fe.field, fe.param); err == nil {
+		return msg
+	}
+	if fe.msg != "" {
+		return fe.msg
+	}
+	return fe.Error()
+}
+
+func (fe *fieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", fe.ns, fe.field, fe.tag)
+}
+
+// wrappedError is a [validator.FieldError] annotated by verify: moved under
+// the namespace of its position in the outer struct when the value was
+// validated on its own, flagged as a warning, given a field's code, with
+// its value redacted or carrying the warnings of the struct.
+type wrappedError struct {
+	validator.FieldError
+	ns, structNs string
+	warning      bool   // reported by a "warn" tag
+	code         string // from the "code" tag of the field
+	redacted     bool   // value replaces the value of FieldError
+	value        any
+	warnings     validator.ValidationErrors // of the "warn" tags, on the first violation
+}
+
+func (e *wrappedError) Namespace() string       { return e.ns }
+func (e *wrappedError) StructNamespace() string { return e.structNs }
+
+func (e *wrappedError) Value() any {
+	if e.redacted {
+		return e.value
+	}
+	return e.FieldError.Value()
+}
+
+func (e *wrappedError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.ns, e.Field(), e.Tag())
+}
+
+// annotate gives the violations in err the codes of the "code" tags of
+// their fields in s and redacts the values of sensitive fields.
+func (ver *Verifier) annotate(s any, err error) error {
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if !ok || len(valErrs) == 0 {
+		return err
+	}
+	t := reflect.TypeOf(s)
+	if !hasStructTag(t, codeTag) && !hasStructTag(t, redactTag) && !hasStructTag(t, sensitiveTag) {
+		return err
+	}
+	codes := make(map[string]string)
+	redactors := make(map[string]RedactFunc)
+	ver.walkFields(s, func(n *fieldNode) bool {
+		if spec, ok := n.field.Tag.Lookup(codeTag); ok {
+			codes[n.structNs] = spec
+		}
+		if fn := ver.redactor(n.field); fn != nil {
+			redactors[n.structNs] = fn
+			return false // nested values are redacted with the field
+		}
+		return true
+	})
+
+	for i, fe := range valErrs {
+		// Elements reached through dive use the code of their field, and
+		// anything inside a sensitive field its strategy.
+		var code string
+		var redact RedactFunc
+		for ns := fe.StructNamespace(); ns != ""; {
+			if spec, ok := codes[ns]; ok && code == "" && !strings.ContainsAny(fe.StructNamespace()[len(ns):], ".") {
+				code = fieldCode(spec, fe.Tag())
+			}
+			if fn, ok := redactors[ns]; ok {
+				redact = fn
+				break
+			}
+			j := strings.LastIndexAny(ns, ".[")
+			if j < 0 {
+				break
+			}
+			ns = ns[:j]
+		}
+		if code == "" && redact == nil {
+			continue
+		}
+		w, ok := fe.(*wrappedError)
+		if ok {
+			c := *w
+			w = &c
+		} else {
+			w = &wrappedError{FieldError: fe, ns: fe.Namespace(), structNs: fe.StructNamespace()}
+		}
+		if code != "" {
+			w.code = code
+		}
+		if redact != nil {
+			w.value, w.redacted = redact(fe.Value()), true
+		}
+		valErrs[i] = w
+	}
+	return valErrs
+}
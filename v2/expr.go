@@ -0,0 +1,879 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file implements the expression language of [Rule]:
+//
+//	rules      = rule { ";" rule }
+//	rule       = expr [ "=>" expr ] [ "|" string ]
+//	expr       = and { ( "||" | "or" ) and }
+//	and        = not { ( "&&" | "and" ) not }
+//	not        = ( "!" | "not" ) not | comparison
+//	comparison = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) sum ]
+//	sum        = product { ( "+" | "-" ) product }
+//	product    = unary { ( "*" | "/" | "%" ) unary }
+//	unary      = "-" unary | primary
+//	primary    = number | string | "true" | "false" | "null" | "nil"
+//	           | "(" expr ")" | "[" [ expr { "," expr } ] "]"
+//	           | name "(" [ expr { "," expr } ] ")" | path
+//	path       = ( name | "this" | "$parent" | "$root" ) { "." name }
+
+// ---------- Lexer ----------
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string // operator or identifier; unquoted string
+	num  float64
+	pos  int
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r >= '0' && r <= '9' || r == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			j := i
+			for j < len(src) && (src[j] >= '0' && src[j] <= '9' || src[j] == '.' || src[j] == '_') {
+				j++
+			}
+			n, err := strconv.ParseFloat(strings.ReplaceAll(src[i:j], "_", ""), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at offset %d", src[i:j], i)
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[i:j], num: n, pos: i})
+			i = j
+		case r == '\'' || r == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && rune(src[j]) != r; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				b.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			toks = append(toks, token{kind: tokString, text: b.String(), pos: i})
+			i = j + 1
+		case r == '$' || r == '_' || unicode.IsLetter(r):
+			j := i + size
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += size
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, cand := range []string{"=>", "==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", ".", "|", ";"} {
+				if strings.HasPrefix(src[i:], cand) {
+					op = cand
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", r, i)
+			}
+			toks = append(toks, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// ---------- Parser ----------
+
+type parser struct {
+	src  string
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+
+// unread steps back over t, the token returned by the last next.
+func (p *parser) unread(t token) {
+	if t.kind != tokEOF {
+		p.pos--
+	}
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators or
+// keywords.
+func (p *parser) accept(texts ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return "", false
+	}
+	for _, text := range texts {
+		if t.text == text {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(text string) error {
+	if _, ok := p.accept(text); !ok {
+		return p.errorf("expected %q", text)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	t := p.peek()
+	at := "end of rule"
+	if t.kind != tokEOF {
+		at = fmt.Sprintf("%q at offset %d", p.src[t.pos:], t.pos)
+		if len(p.src)-t.pos > 16 {
+			at = fmt.Sprintf("%q at offset %d", p.src[t.pos:t.pos+16]+"…", t.pos)
+		}
+	}
+	return fmt.Errorf(format+" near %s", append(args, at)...)
+}
+
+func (p *parser) parseRules() ([]*ruleClause, error) {
+	var clauses []*ruleClause
+	for {
+		if _, ok := p.accept(";"); ok {
+			continue
+		}
+		if p.peek().kind == tokEOF {
+			break
+		}
+		c, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, c)
+		if p.peek().kind != tokEOF {
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, errors.New("empty rule")
+	}
+	return clauses, nil
+}
+
+func (p *parser) parseRule() (*ruleClause, error) {
+	start := p.peek().pos
+	c := &ruleClause{}
+	x, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	c.assert = x
+	if _, ok := p.accept("=>"); ok {
+		c.cond = x
+		if c.assert, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	c.src = strings.TrimSpace(p.src[start:p.peek().pos])
+	if _, ok := p.accept("|"); ok {
+		t := p.next()
+		if t.kind != tokString {
+			p.unread(t)
+			return nil, p.errorf("expected message string")
+		}
+		c.message = t.text
+	}
+	return c, nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return x, nil
+		}
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &logicExpr{or: true, x: x, y: y}
+	}
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return x, nil
+		}
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &logicExpr{x: x, y: y}
+	}
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">", "in")
+	if !ok {
+		return x, nil
+	}
+	y, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryExpr{op: op, x: x, y: y}, nil
+}
+
+func (p *parser) parseSum() (expr, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) parseProduct() (expr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if _, ok := p.accept("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpr{op: "-", x: literal{v: 0.0}, y: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return literal{v: t.num}, nil
+	case tokString:
+		return literal{v: t.text}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		case "[":
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listExpr{items: items}, nil
+		}
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return literal{v: true}, nil
+		case "false":
+			return literal{v: false}, nil
+		case "null", "nil":
+			return literal{v: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(t.text)
+		}
+		ref := &refExpr{scope: scopeSibling}
+		switch t.text {
+		case "this":
+			ref.scope = scopeThis
+		case "$parent":
+			ref.scope = scopeParent
+		case "$root":
+			ref.scope = scopeRoot
+		default:
+			if strings.HasPrefix(t.text, "$") {
+				p.unread(t)
+				return nil, p.errorf("unknown reference %q", t.text)
+			}
+			ref.path = []string{t.text}
+		}
+		for {
+			if _, ok := p.accept("."); !ok {
+				break
+			}
+			name := p.next()
+			if name.kind != tokIdent {
+				p.unread(name)
+				return nil, p.errorf("expected field name")
+			}
+			ref.path = append(ref.path, name.text)
+		}
+		if ref.scope != scopeThis && len(ref.path) == 0 {
+			return nil, p.errorf("%s must be followed by a field", t.text)
+		}
+		return ref, nil
+	}
+	p.unread(t)
+	return nil, p.errorf("unexpected token")
+}
+
+func (p *parser) parseList(end string) ([]expr, error) {
+	var items []expr
+	if _, ok := p.accept(end); ok {
+		return items, nil
+	}
+	for {
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, x)
+		if _, ok := p.accept(end); ok {
+			return items, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseCall(name string) (expr, error) {
+	arity, ok := ruleFuncArity[name]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+	args, err := p.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != arity {
+		return nil, p.errorf("%s expects %d argument(s), got %d", name, arity, len(args))
+	}
+	c := &callExpr{name: name, args: args}
+	if name == "matches" {
+		lit, ok := args[1].(literal)
+		pattern, isString := lit.v.(string)
+		if !ok || !isString {
+			return nil, p.errorf("matches expects a string literal pattern")
+		}
+		if c.re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("matches: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// ---------- Evaluation ----------
+
+// ruleEnv is the evaluation scope of a rule: the value of the field it is
+// attached to and the containers of its sibling, parent and root fields,
+// which are structs or maps.
+type ruleEnv struct {
+	ver     *Verifier
+	this    reflect.Value
+	sibling reflect.Value
+	parent  reflect.Value
+	root    reflect.Value
+}
+
+type expr interface {
+	eval(env *ruleEnv) (any, error)
+}
+
+type literal struct{ v any }
+
+func (l literal) eval(*ruleEnv) (any, error) { return l.v, nil }
+
+type refScope int
+
+const (
+	scopeSibling refScope = iota
+	scopeThis
+	scopeParent
+	scopeRoot
+)
+
+type refExpr struct {
+	scope refScope
+	path  []string
+}
+
+func (r *refExpr) eval(env *ruleEnv) (any, error) {
+	var v reflect.Value
+	switch r.scope {
+	case scopeThis:
+		v = env.this
+	case scopeSibling:
+		v = env.sibling
+	case scopeParent:
+		v = env.parent
+		if !v.IsValid() {
+			return nil, errors.New("$parent used outside a nested value")
+		}
+	case scopeRoot:
+		v = env.root
+	}
+	for _, name := range r.path {
+		next, err := env.ver.member(v, name)
+		if err != nil {
+			return nil, err
+		}
+		v = next
+	}
+	return normalize(v), nil
+}
+
+// member returns the field or map entry name of v.
+func (ver *Verifier) member(v reflect.Value, name string) (reflect.Value, error) {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		if i, ok := ver.fieldIndex(v.Type())[name]; ok {
+			return v.Field(i), nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())), nil
+		}
+	case reflect.Invalid:
+		return reflect.Value{}, nil
+	}
+	return reflect.Value{}, fmt.Errorf("unknown field %q in %s", name, v.Type())
+}
+
+// fieldIndex maps the names and tag names of the exported fields of t to
+// their index.
+func (ver *Verifier) fieldIndex(t reflect.Type) map[string]int {
+	if idx, ok := ver.fieldIndexes.Load(t); ok {
+		return idx.(map[string]int)
+	}
+	idx := make(map[string]int, t.NumField())
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if !fld.IsExported() {
+			continue
+		}
+		idx[fld.Name] = i
+		if alt := ver.tagNameFunc(fld); alt != "" {
+			idx[alt] = i
+		}
+	}
+	ver.fieldIndexes.Store(t, idx)
+	return idx
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// normalize converts v into nil, bool, float64, string, time.Time, []any
+// or, for structs and maps, a reflect.Value.
+func normalize(v reflect.Value) any {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case time.Time:
+			return x
+		case interface{ optionalValue() (any, bool) }:
+			val, ok := x.optionalValue()
+			if !ok {
+				return nil
+			}
+			return normalize(reflect.ValueOf(val))
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []any{}
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = normalize(v.Index(i))
+		}
+		return out
+	}
+	return v
+}
+
+type logicExpr struct {
+	or   bool
+	x, y expr
+}
+
+func (e *logicExpr) eval(env *ruleEnv) (any, error) {
+	x, err := evalBool(e.x, env)
+	if err != nil || x == e.or {
+		return x, err
+	}
+	return evalBool(e.y, env)
+}
+
+type notExpr struct{ x expr }
+
+func (e *notExpr) eval(env *ruleEnv) (any, error) {
+	x, err := evalBool(e.x, env)
+	return !x, err
+}
+
+func evalBool(x expr, env *ruleEnv) (bool, error) {
+	v, err := x.eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean, got %s", typeName(v))
+	}
+	return b, nil
+}
+
+type binaryExpr struct {
+	op   string
+	x, y expr
+}
+
+func (e *binaryExpr) eval(env *ruleEnv) (any, error) {
+	x, err := e.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	y, err := e.y.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "==":
+		return equal(x, y), nil
+	case "!=":
+		return !equal(x, y), nil
+	case "in":
+		list, ok := y.([]any)
+		if !ok {
+			return nil, fmt.Errorf("in expects a list, got %s", typeName(y))
+		}
+		for _, item := range list {
+			if equal(x, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "<", "<=", ">", ">=":
+		c, err := compare(x, y)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+
+	if e.op == "+" {
+		if xs, ok := x.(string); ok {
+			if ys, ok := y.(string); ok {
+				return xs + ys, nil
+			}
+		}
+	}
+	xf, xok := x.(float64)
+	yf, yok := y.(float64)
+	if !xok || !yok {
+		return nil, fmt.Errorf("cannot apply %s to %s and %s", e.op, typeName(x), typeName(y))
+	}
+	switch e.op {
+	case "+":
+		return xf + yf, nil
+	case "-":
+		return xf - yf, nil
+	case "*":
+		return xf * yf, nil
+	case "/":
+		if yf == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return xf / yf, nil
+	default:
+		if yf == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return math.Mod(xf, yf), nil
+	}
+}
+
+func equal(x, y any) bool {
+	switch xv := x.(type) {
+	case nil:
+		return y == nil
+	case time.Time:
+		yv, ok := y.(time.Time)
+		return ok && xv.Equal(yv)
+	case []any:
+		yv, ok := y.([]any)
+		if !ok || len(xv) != len(yv) {
+			return false
+		}
+		for i := range xv {
+			if !equal(xv[i], yv[i]) {
+				return false
+			}
+		}
+		return true
+	case reflect.Value:
+		return false
+	}
+	return x == y
+}
+
+func compare(x, y any) (int, error) {
+	switch xv := x.(type) {
+	case float64:
+		if yv, ok := y.(float64); ok {
+			switch {
+			case xv < yv:
+				return -1, nil
+			case xv > yv:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if yv, ok := y.(string); ok {
+			return strings.Compare(xv, yv), nil
+		}
+	case time.Time:
+		if yv, ok := y.(time.Time); ok {
+			return xv.Compare(yv), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", typeName(x), typeName(y))
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	case []any:
+		return "list"
+	}
+	return "object"
+}
+
+type listExpr struct{ items []expr }
+
+func (e *listExpr) eval(env *ruleEnv) (any, error) {
+	out := make([]any, len(e.items))
+	for i, item := range e.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+var ruleFuncArity = map[string]int{
+	"len":      1,
+	"empty":    1,
+	"contains": 2,
+	"matches":  2,
+	"lower":    1,
+	"upper":    1,
+	"now":      0,
+}
+
+type callExpr struct {
+	name string
+	args []expr
+	re   *regexp.Regexp // compiled pattern of matches
+}
+
+func (e *callExpr) eval(env *ruleEnv) (any, error) {
+	args := make([]any, len(e.args))
+	for i, arg := range e.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	switch e.name {
+	case "len":
+		switch v := args[0].(type) {
+		case string:
+			return float64(utf8.RuneCountInString(v)), nil
+		case []any:
+			return float64(len(v)), nil
+		case nil:
+			return 0.0, nil
+		case reflect.Value:
+			if v.Kind() == reflect.Map {
+				return float64(v.Len()), nil
+			}
+		}
+	case "empty":
+		switch v := args[0].(type) {
+		case nil:
+			return true, nil
+		case string:
+			return v == "", nil
+		case float64:
+			return v == 0, nil
+		case bool:
+			return !v, nil
+		case []any:
+			return len(v) == 0, nil
+		case time.Time:
+			return v.IsZero(), nil
+		case reflect.Value:
+			return v.IsZero() || v.Kind() == reflect.Map && v.Len() == 0, nil
+		}
+	case "contains":
+		switch v := args[0].(type) {
+		case string:
+			if sub, ok := args[1].(string); ok {
+				return strings.Contains(v, sub), nil
+			}
+		case []any:
+			for _, item := range v {
+				if equal(item, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	case "matches":
+		if s, ok := args[0].(string); ok {
+			return e.re.MatchString(s), nil
+		}
+	case "lower", "upper":
+		if s, ok := args[0].(string); ok {
+			if e.name == "lower" {
+				return strings.ToLower(s), nil
+			}
+			return strings.ToUpper(s), nil
+		}
+	case "now":
+		return time.Now(), nil
+	}
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = typeName(arg)
+	}
+	return nil, fmt.Errorf("%s does not accept (%s)", e.name, strings.Join(types, ", "))
+}
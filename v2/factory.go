@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"github.com/gtkit/goerr"
+)
+
+// ErrorKind tells an [ErrorFactory] which helper reports the error.
+type ErrorKind int
+
+const (
+	FieldErrorKind  ErrorKind = iota // [Verifier.FieldErr]
+	StructErrorKind                  // [Verifier.StructErr]
+	MapErrorKind                     // [Verifier.MapErr]
+)
+
+// ErrorReport is what an [ErrorFactory] turns into an error.
+type ErrorReport struct {
+	Kind   ErrorKind
+	Locale string // locale of the Verifier
+	// Message is the translated message of the reported violation, after
+	// the field name for FieldErr and MapErr. Empty if Invalid.
+	Message string
+	// Violation is the reported violation; nil if Invalid or if a map
+	// value did not hold validation errors.
+	Violation *FieldViolation
+	// Status is the goerr status registered for the code of Violation,
+	// see [Verifier.RegisterCodeStatus], else goerr.StatusValidateParams().
+	Status *goerr.Status
+	// Invalid is set when Cause is not a validation error, such as an
+	// invalid argument.
+	Invalid bool
+	Cause   error // the error given to FieldErr or StructErr
+}
+
+// ErrorFactory builds the errors returned by [Verifier.FieldErr],
+// [Verifier.StructErr] and [Verifier.MapErr] from a report. It must not
+// return nil. The default is [DefaultErrorFactory].
+//
+//	verify.WithErrorFactory(func(r verify.ErrorReport) error {
+//	    if r.Invalid {
+//	        return r.Cause
+//	    }
+//	    return &verify.Errors{Violations: []verify.FieldViolation{*r.Violation}}
+//	})
+type ErrorFactory func(ErrorReport) error
+
+// WithErrorFactory sets how FieldErr, StructErr and MapErr build their
+// errors. Default: [DefaultErrorFactory].
+func WithErrorFactory(f ErrorFactory) Option {
+	return func(c *config) { c.errorFactory = f }
+}
+
+// DefaultErrorFactory returns a goerr error with the report's status,
+// wrapped with the same Chinese message whatever the locale, e.g.
+// "结构验证错误: name为必填字段". Errors that are not validation errors are
+// wrapped as they are.
+func DefaultErrorFactory(r ErrorReport) error {
+	return goerrFactory(r, "zh")
+}
+
+// LocalizedErrorFactory is like [DefaultErrorFactory] but wraps with the
+// message in the report's locale, e.g. "struct validation error" for "en",
+// falling back to English.
+//
+//	v := verify.MustNew(verify.WithLocale("en"), verify.WithErrorFactory(verify.LocalizedErrorFactory))
+func LocalizedErrorFactory(r ErrorReport) error {
+	return goerrFactory(r, r.Locale)
+}
+
+// goerrFactory builds the goerr error of r wrapped with the message of
+// locale.
+func goerrFactory(r ErrorReport, locale string) error {
+	if r.Invalid {
+		return goerr.New(r.Cause, r.Status, errorText(locale, "invalid"))
+	}
+	key := "struct"
+	switch r.Kind {
+	case FieldErrorKind:
+		key = "field"
+	case MapErrorKind:
+		key = "map"
+	}
+	return goerr.New(goerr.Err(r.Message), r.Status, errorText(locale, key))
+}
+
+var errorTexts = map[string]map[string]string{
+	"zh": {
+		"field":   "字段验证错误",
+		"struct":  "结构验证错误",
+		"map":     "映射验证错误",
+		"invalid": "非ValidationErrors类型错误",
+	},
+	"en": {
+		"field":   "field validation error",
+		"struct":  "struct validation error",
+		"map":     "map validation error",
+		"invalid": "not a validation error",
+	},
+}
+
+// errorText returns a localized wrap message, falling back to English.
+func errorText(locale, key string) string {
+	if texts, ok := errorTexts[locale]; ok {
+		return texts[key]
+	}
+	return errorTexts["en"][key]
+}
@@ -0,0 +1,69 @@
+package verify_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gtkit/goerr"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+func TestDefaultErrorFactory_Locale(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	err := v.StructErr(v.Struct(Signup{Email: "a@b.com"}))
+
+	var item *goerr.Item
+	if !errors.As(err, &item) || item.Code() != goerr.StatusValidateParams().Code() {
+		t.Fatalf("expected a goerr error, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "结构验证错误") || !strings.Contains(msg, "name is a required field") {
+		t.Fatalf("expected the fixed wrap message, got %q", msg)
+	}
+}
+
+func TestLocalizedErrorFactory(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"), verify.WithErrorFactory(verify.LocalizedErrorFactory))
+	err := v.StructErr(v.Struct(Signup{Email: "a@b.com"}))
+	if msg := err.Error(); !strings.Contains(msg, "struct validation error") || !strings.Contains(msg, "name is a required field") {
+		t.Fatalf("expected English wrap message, got %q", msg)
+	}
+}
+
+func TestWithErrorFactory(t *testing.T) {
+	var reports []verify.ErrorReport
+	v := verify.MustNew(verify.WithErrorFactory(func(r verify.ErrorReport) error {
+		reports = append(reports, r)
+		if r.Invalid {
+			return r.Cause
+		}
+		return errors.New(r.Message)
+	}))
+
+	err := v.StructErr(v.Struct(Signup{Email: "a@b.com"}))
+	if err == nil || err.Error() != "name为必填字段" {
+		t.Fatalf("expected a plain error, got %v", err)
+	}
+	r := reports[0]
+	if r.Kind != verify.StructErrorKind || r.Locale != "zh" || r.Violation == nil || r.Violation.Code != "NAME_MISSING" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+
+	if err := v.FieldErr("type", v.Field("x", "numeric")); err == nil || !strings.HasPrefix(err.Error(), "type ") {
+		t.Fatalf("expected the field name first, got %v", err)
+	}
+	if reports[1].Kind != verify.FieldErrorKind {
+		t.Fatalf("unexpected kind %v", reports[1].Kind)
+	}
+
+	cause := errors.New("boom")
+	if err := v.StructErr(cause); err != cause || !reports[2].Invalid {
+		t.Fatalf("expected the cause back, got %v", err)
+	}
+
+	err = v.MapErr(v.Map(map[string]any{"name": ""}, map[string]any{"name": "required"}))
+	if err == nil || reports[3].Kind != verify.MapErrorKind || reports[3].Violation.Field != "name" {
+		t.Fatalf("unexpected map report %+v: %v", reports[3], err)
+	}
+}
@@ -0,0 +1,70 @@
+// Package fiberx installs a [verify.Verifier] as the struct validator of a
+// Fiber app and renders bind and validation errors.
+//
+//	v := verify.MustNew(verify.WithLocale("zh"))
+//	app := fiber.New(fiber.Config{StructValidator: fiberx.NewValidator(v)})
+//
+//	app.Post("/signup", func(c fiber.Ctx) error {
+//	    params, err := fiberx.Bind[SignUpParams](c)
+//	    if err != nil {
+//	        return fiberx.Error(c, err)
+//	    }
+//	    return c.JSON(params)
+//	})
+package fiberx
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	verify "github.com/gtkit/verify/v2"
+)
+
+// Validator implements fiber.StructValidator with a [verify.Verifier].
+type Validator struct {
+	ver *verify.Verifier
+}
+
+// NewValidator returns a fiber.StructValidator backed by ver.
+func NewValidator(ver *verify.Verifier) *Validator {
+	return &Validator{ver: ver}
+}
+
+// Validate validates out with the "binding" tags and async checks.
+func (v *Validator) Validate(out any) error {
+	return v.ver.Struct(out)
+}
+
+// Verifier returns the underlying Verifier.
+func (v *Validator) Verifier() *verify.Verifier { return v.ver }
+
+// Bind binds the request body into a new T with c.Bind().Body, which also
+// validates it with the app's struct validator.
+func Bind[T any](c fiber.Ctx) (T, error) {
+	var obj T
+	err := c.Bind().Body(&obj)
+	return obj, err
+}
+
+// Error writes the [verify.ErrorResponse] for err, localized by the
+// Verifier installed with [NewValidator]. Bind and validation failures are
+// answered with 400; other Fiber errors keep their status code.
+func Error(c fiber.Ctx, err error) error {
+	if fe, ok := errors.AsType[*fiber.Error](err); ok {
+		return c.Status(fe.Code).JSON(verify.ErrorResponse{Message: fe.Message})
+	}
+	ver := verifier(c)
+	if ver == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(verify.ErrorResponse{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(ver.Response(err))
+}
+
+// verifier returns the Verifier of the app's struct validator, falling back
+// to the package-level default, which may be nil.
+func verifier(c fiber.Ctx) *verify.Verifier {
+	if v, ok := c.App().Config().StructValidator.(*Validator); ok {
+		return v.ver
+	}
+	return verify.Default()
+}
@@ -0,0 +1,65 @@
+package fiberx_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/fiberx"
+)
+
+type SignUpParams struct {
+	Name  string `json:"name" binding:"required,min=2"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+func TestBind(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		StructValidator: fiberx.NewValidator(verify.MustNew(verify.WithLocale("zh"))),
+	})
+	app.Post("/signup", func(c fiber.Ctx) error {
+		params, err := fiberx.Bind[SignUpParams](c)
+		if err != nil {
+			return fiberx.Error(c, err)
+		}
+		return c.JSON(params)
+	})
+
+	cases := []struct {
+		body, ctype, field, message string
+		code                        int
+	}{
+		{`{"name":"alice","email":"a@b.com"}`, fiber.MIMEApplicationJSON, "", "", 200},
+		{`{"name":"a","email":"a@b.com"}`, fiber.MIMEApplicationJSON, "name", "name长度必须至少为2个字符", 400},
+		{`{"name":1}`, fiber.MIMEApplicationJSON, "name", "name类型不正确", 400},
+		{`{"name":`, fiber.MIMEApplicationJSON, "", "请求体格式错误", 400},
+		{`name`, "application/x-unknown", "", "Unprocessable Entity", 422},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tc.body))
+		req.Header.Set(fiber.HeaderContentType, tc.ctype)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		if res.StatusCode != tc.code {
+			t.Fatalf("%s: expected %d, got %d %s", tc.body, tc.code, res.StatusCode, body)
+		}
+		if tc.code == 200 {
+			continue
+		}
+		var resp verify.ErrorResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Message != tc.message || tc.field != "" && resp.Errors[0].Field != tc.field {
+			t.Fatalf("%s: unexpected response %+v", tc.body, resp)
+		}
+	}
+}
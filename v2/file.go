@@ -0,0 +1,269 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for image_max_dim
+	_ "image/jpeg"
+	_ "image/png"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+)
+
+// File tags apply to *multipart.FileHeader fields, and to every file of a
+// []*multipart.FileHeader field:
+//
+//	file_maxsize=5MB                 size at most 5 MiB (B, KB, MB and GB are powers of 1024)
+//	file_mime=image/png image/jpeg   MIME type sniffed from the content, "image/*" matches a prefix
+//	file_ext=.png .jpg               file name extension, ignoring case
+//	image_max_dim=4096x4096          a PNG, JPEG or GIF image of at most 4096×4096 pixels
+//	files_max=5                      at most 5 files
+//
+// Lists are separated by spaces; "|" is the validator's or operator and
+// must be written as 0x7C. Files are opened to sniff their content and
+// dimensions, so unreadable files fail.
+//
+//	type Upload struct {
+//	    Avatar *multipart.FileHeader   `form:"avatar" binding:"required,file_maxsize=2MB,file_mime=image/png image/jpeg"`
+//	    Photos []*multipart.FileHeader `form:"photos" binding:"files_max=5,file_mime=image/*,image_max_dim=4096x4096"`
+//	}
+var fileValidations = map[string]validator.Func{
+	"file_maxsize":  eachFile(fileMaxSize),
+	"file_mime":     eachFile(fileMIME),
+	"file_ext":      eachFile(fileExt),
+	"image_max_dim": eachFile(imageMaxDim),
+	"files_max":     filesMax,
+}
+
+var fileTexts = map[string]map[string]string{
+	"zh": {
+		"file_maxsize":  "{0}不能超过{1}",
+		"file_mime":     "{0}的文件类型必须是{1}之一",
+		"file_ext":      "{0}的扩展名必须是{1}之一",
+		"image_max_dim": "{0}必须是尺寸不超过{1}的图片",
+		"files_max":     "{0}最多只能上传{1}个文件",
+	},
+	"en": {
+		"file_maxsize":  "{0} must not exceed {1}",
+		"file_mime":     "{0} must be a file of type {1}",
+		"file_ext":      "{0} must have one of the extensions {1}",
+		"image_max_dim": "{0} must be an image of at most {1} pixels",
+		"files_max":     "{0} must contain at most {1} files",
+	},
+}
+
+func fileText(locale, tag string) string {
+	if texts, ok := fileTexts[locale]; ok {
+		return texts[tag]
+	}
+	return fileTexts["en"][tag]
+}
+
+// registerFileValidations registers the file tags with their translations.
+func (ver *Verifier) registerFileValidations() error {
+	for tag, fn := range fileValidations {
+		if err := ver.validate.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+		msg := fileText(ver.locale, tag)
+		if err := ver.validate.RegisterTranslation(tag, ver.trans, RegisterTranslator(tag, msg), translateActual); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	fileHeaderType  = reflect.TypeFor[multipart.FileHeader]()
+	fileHeadersType = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// eachFile adapts a check of a single file to a validator.Func accepting a
+// file header or a slice of them. Nil files are skipped.
+func eachFile(check func(fh *multipart.FileHeader, param string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		switch {
+		case field.Type() == fileHeaderType:
+			fh := field.Interface().(multipart.FileHeader)
+			return check(&fh, fl.Param())
+		case field.Type() == fileHeadersType:
+			for _, fh := range field.Interface().([]*multipart.FileHeader) {
+				if fh != nil && !check(fh, fl.Param()) {
+					return false
+				}
+			}
+			return true
+		}
+		panic(fmt.Sprintf("verify: tag %q requires *multipart.FileHeader or []*multipart.FileHeader, got %s", fl.GetTag(), field.Type()))
+	}
+}
+
+func fileMaxSize(fh *multipart.FileHeader, param string) bool {
+	n, err := parseByteSize(param)
+	if err != nil {
+		panic(fmt.Sprintf("verify: bad file_maxsize parameter: %v", err))
+	}
+	return fh.Size <= n
+}
+
+// parseByteSize parses sizes such as "512", "100KB" and "5MB".
+func parseByteSize(s string) (int64, error) {
+	num := strings.TrimSpace(strings.ToUpper(s))
+	unit := int64(1)
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if trimmed, ok := strings.CutSuffix(num, u.suffix); ok {
+			num, unit = strings.TrimSpace(trimmed), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * unit, nil
+}
+
+// paramList splits a space or "|" separated tag parameter.
+func paramList(param string) []string {
+	return strings.FieldsFunc(param, func(r rune) bool { return r == ' ' || r == '|' })
+}
+
+func fileMIME(fh *multipart.FileHeader, param string) bool {
+	f, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	detected, err := mimetype.DetectReader(f)
+	if err != nil {
+		return false
+	}
+	for _, want := range paramList(param) {
+		if prefix, ok := strings.CutSuffix(want, "/*"); ok {
+			if strings.HasPrefix(detected.String(), prefix+"/") {
+				return true
+			}
+			continue
+		}
+		// Parents cover subtypes, e.g. application/json is text/plain.
+		for m := detected; m != nil; m = m.Parent() {
+			if m.Is(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fileExt(fh *multipart.FileHeader, param string) bool {
+	ext := filepath.Ext(fh.Filename)
+	for _, want := range paramList(param) {
+		if !strings.HasPrefix(want, ".") {
+			want = "." + want
+		}
+		if strings.EqualFold(ext, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func imageMaxDim(fh *multipart.FileHeader, param string) bool {
+	w, h, ok := strings.Cut(strings.ToLower(param), "x")
+	maxW, errW := strconv.Atoi(w)
+	maxH, errH := strconv.Atoi(h)
+	if !ok || errW != nil || errH != nil {
+		panic(fmt.Sprintf("verify: bad image_max_dim parameter %q, want WIDTHxHEIGHT", param))
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	return err == nil && cfg.Width <= maxW && cfg.Height <= maxH
+}
+
+func filesMax(fl validator.FieldLevel) bool {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("verify: bad files_max parameter %q", fl.Param()))
+	}
+	switch fl.Field().Kind() {
+	case reflect.Slice, reflect.Array:
+		return fl.Field().Len() <= n
+	}
+	panic(fmt.Sprintf("verify: files_max requires a slice, got %s", fl.Field().Type()))
+}
+
+// ---------- net/http ----------
+
+// BindFiles sets the *multipart.FileHeader and []*multipart.FileHeader
+// fields of the struct pointed to by dst from the files of a multipart
+// request, matched by their "form" tag or field name. Gin's ShouldBind does
+// this already; BindFiles is for plain net/http handlers.
+//
+//	var up Upload
+//	if err := verify.BindFiles(r, &up, 32<<20); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//	if err := v.Struct(up); err != nil { ... }
+func BindFiles(r *http.Request, dst any, maxMemory int64) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("verify: BindFiles requires a pointer to a struct, got %T", dst)
+	}
+	if r.MultipartForm == nil {
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			if errors.Is(err, http.ErrNotMultipart) {
+				return nil
+			}
+			return fmt.Errorf("verify: %w", err)
+		}
+	}
+	files := r.MultipartForm.File
+
+	v := rv.Elem()
+	for i := range v.NumField() {
+		fld := v.Type().Field(i)
+		if !fld.IsExported() {
+			continue
+		}
+		name := FormTagName(fld)
+		if name == "" {
+			if fld.Tag.Get("form") == "-" {
+				continue
+			}
+			name = fld.Name
+		}
+		headers := files[name]
+		if len(headers) == 0 {
+			continue
+		}
+		switch fld.Type {
+		case reflect.PointerTo(fileHeaderType):
+			v.Field(i).Set(reflect.ValueOf(headers[0]))
+		case fileHeaderType:
+			v.Field(i).Set(reflect.ValueOf(*headers[0]))
+		case fileHeadersType:
+			v.Field(i).Set(reflect.ValueOf(headers))
+		}
+	}
+	return nil
+}
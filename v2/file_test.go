@@ -0,0 +1,106 @@
+package verify_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Upload struct {
+	Avatar *multipart.FileHeader   `json:"avatar" form:"avatar" binding:"required,file_maxsize=1KB,file_mime=image/png image/jpeg,file_ext=png jpg"`
+	Photos []*multipart.FileHeader `json:"photos" form:"photos" binding:"files_max=2,image_max_dim=20x20"`
+}
+
+type part struct{ field, name string }
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func multipartRequest(t *testing.T, files map[part][]byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for p, content := range files {
+		fw, err := mw.CreateFormFile(p.field, p.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(content)
+	}
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestFileTags(t *testing.T) {
+	v := newVerifier(t)
+	small := pngBytes(t, 10, 10)
+	cases := []struct {
+		name  string
+		files map[part][]byte
+		want  map[string]string
+	}{
+		{"valid", map[part][]byte{{"avatar", "a.png"}: small, {"photos", "1.png"}: small}, nil},
+		{"missing", map[part][]byte{}, map[string]string{"avatar": "avatar为必填字段"}},
+		{"too large", map[part][]byte{{"avatar", "a.png"}: append(pngBytes(t, 10, 10), make([]byte, 2048)...)}, map[string]string{"avatar": "avatar不能超过1KB"}},
+		{"spoofed type", map[part][]byte{{"avatar", "a.png"}: []byte("plain text")}, map[string]string{"avatar": "avatar的文件类型必须是image/png image/jpeg之一"}},
+		{"extension", map[part][]byte{{"avatar", "a.gif"}: small}, map[string]string{"avatar": "avatar的扩展名必须是png jpg之一"}},
+		{"dimensions", map[part][]byte{{"avatar", "a.png"}: small, {"photos", "1.png"}: pngBytes(t, 30, 10)}, map[string]string{"photos": "photos必须是尺寸不超过20x20的图片"}},
+		{"count", map[part][]byte{{"avatar", "a.png"}: small, {"photos", "1.png"}: small, {"photos", "2.png"}: small, {"photos", "3.png"}: small}, map[string]string{"photos": "photos最多只能上传2个文件"}},
+	}
+	for _, tc := range cases {
+		var up Upload
+		if err := verify.BindFiles(multipartRequest(t, tc.files), &up, 1<<20); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		got := v.AllFieldErrors(v.Struct(up))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+		for k, msg := range tc.want {
+			if got[k] != msg {
+				t.Fatalf("%s: expected %s=%q, got %v", tc.name, k, msg, got)
+			}
+		}
+	}
+}
+
+func TestFileTags_Gin(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	r := gin.New()
+	r.Use(v.GinMiddleware())
+	r.POST("/upload", func(c *gin.Context) {
+		var up Upload
+		if err := verify.ShouldBind(c, &up); err != nil {
+			v.GinAbort(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, multipartRequest(t, map[part][]byte{{"avatar", "a.png"}: pngBytes(t, 10, 10)}))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, multipartRequest(t, map[part][]byte{{"avatar", "a.png"}: []byte("GIF89a")}))
+	if w.Code != http.StatusBadRequest || !bytes.Contains(w.Body.Bytes(), []byte("avatar must be a file of type image/png image/jpeg")) {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+}
@@ -1,21 +1,161 @@
 package verify
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-yaml"
 	"github.com/gtkit/goerr"
+	"github.com/pelletier/go-toml/v2"
 )
 
-// bindToGin replaces Gin's built-in validator engine.
-func bindToGin(v *validator.Validate) error {
-	binding.Validator = &ginValidator{v: v}
+// ginContextKey is the gin.Context key holding the Verifier set by
+// [Verifier.GinMiddleware].
+const ginContextKey = "github.com/gtkit/verify/v2.verifier"
+
+var ginMu sync.Mutex // guards binding.Validator
+
+// bindToGin replaces Gin's built-in validator engine. Replacing the
+// Verifier installed by another [WithGinBinding] logs a warning, since
+// the last one silently wins for every route.
+func bindToGin(ver *Verifier) error {
+	ginMu.Lock()
+	defer ginMu.Unlock()
+
+	if prev, ok := binding.Validator.(*ginValidator); ok && prev.ver != ver {
+		slog.Warn("verify: gin binding.Validator overwritten by another Verifier; use GinMiddleware for per-route Verifiers",
+			"previous_locale", prev.ver.locale, "locale", ver.locale)
+	}
+	binding.Validator = &ginValidator{ver: ver}
+	return nil
+}
+
+// ginValidator is the binding.Validator installed by [WithGinBinding].
+type ginValidator struct{ ver *Verifier }
+
+func (g *ginValidator) ValidateStruct(obj any) error {
+	if err := g.ver.Struct(obj); err != nil {
+		return goerr.WithStack(err)
+	}
+	return nil
+}
+
+func (g *ginValidator) Engine() any { return g.ver.validate }
+
+// GinMiddleware returns a Gin middleware that makes ver the Verifier of
+// the requests it handles, for [ShouldBind], [GinBind] and [GinError].
+// Unlike [WithGinBinding] it leaves Gin's validator alone, so several
+// Verifiers can serve one engine:
+//
+//	api := r.Group("/api", zh.GinMiddleware())
+//	admin := r.Group("/admin", en.GinMiddleware())
+//
+// Plain c.ShouldBind keeps validating with Gin's validator.
+func (ver *Verifier) GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ginContextKey, ver)
+		c.Next()
+	}
+}
+
+// GinVerifier returns the Verifier set by [Verifier.GinMiddleware] for c,
+// else the one installed by [WithGinBinding], else nil.
+func GinVerifier(c *gin.Context) *Verifier {
+	if ver, ok := c.Value(ginContextKey).(*Verifier); ok {
+		return ver
+	}
+	ginMu.Lock()
+	defer ginMu.Unlock()
+	if g, ok := binding.Validator.(*ginValidator); ok {
+		return g.ver
+	}
+	return nil
+}
+
+// ShouldBind binds the request into obj like c.ShouldBind and validates it
+// with the Verifier of c (see [GinVerifier]), using the request context for
+// async checks. Gin's validator does not run on JSON, XML, YAML, TOML and
+// form bodies; other bodies are decoded by Gin's binding, which runs it.
+//
+//	var params SignUpParams
+//	if err := verify.ShouldBind(c, &params); err != nil {
+//	    verify.GinError(c, err)
+//	    return
+//	}
+func ShouldBind(c *gin.Context, obj any) error {
+	ver := GinVerifier(c)
+	if ver == nil {
+		return c.ShouldBind(obj)
+	}
+	if err := ginDecode(c, obj); err != nil {
+		return err
+	}
+	if err := ver.StructCtx(c.Request.Context(), obj); err != nil {
+		return goerr.WithStack(err)
+	}
 	return nil
 }
 
-type ginValidator struct{ v *validator.Validate }
+// ginDefaultMemory is the multipart memory limit of Gin's form bindings.
+const ginDefaultMemory = 32 << 20
 
-func (g *ginValidator) ValidateStruct(obj any) error { return goerr.WithStack(g.v.Struct(obj)) }
-func (g *ginValidator) Engine() any                  { return g.v }
+// ginDecode decodes the request into obj like the binding c.ShouldBind
+// picks, without calling Gin's validator where it can.
+func ginDecode(c *gin.Context, obj any) error {
+	req := c.Request
+	b := binding.Default(req.Method, c.ContentType())
+	if b != binding.Form && b != binding.FormPost && b != binding.FormMultipart && req.Body == nil {
+		return errors.New("invalid request")
+	}
+	switch b {
+	case binding.JSON:
+		dec := json.NewDecoder(req.Body)
+		if binding.EnableDecoderUseNumber {
+			dec.UseNumber()
+		}
+		if binding.EnableDecoderDisallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+		return dec.Decode(obj)
+	case binding.XML:
+		return xml.NewDecoder(req.Body).Decode(obj)
+	case binding.YAML:
+		return yaml.NewDecoder(req.Body).Decode(obj)
+	case binding.TOML:
+		return toml.NewDecoder(req.Body).Decode(obj)
+	case binding.Form:
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+		if err := req.ParseMultipartForm(ginDefaultMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return err
+		}
+		if err := binding.MapFormWithTag(obj, req.Form, "form"); err != nil {
+			return err
+		}
+		return BindFiles(req, obj, ginDefaultMemory)
+	case binding.FormPost:
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, req.PostForm, "form")
+	case binding.FormMultipart:
+		if err := req.ParseMultipartForm(ginDefaultMemory); err != nil {
+			return err
+		}
+		if err := binding.MapFormWithTag(obj, req.MultipartForm.Value, "form"); err != nil {
+			return err
+		}
+		return BindFiles(req, obj, ginDefaultMemory)
+	}
+	return c.ShouldBindWith(obj, b)
+}
 
 // GinStructErr translates an error from Gin's c.ShouldBind into a
 // human-readable error, same as [Verifier.StructErr].
@@ -31,3 +171,32 @@ func (ver *Verifier) GinStructErr(err error) error {
 func (ver *Verifier) GinFieldErr(field string, err error) error {
 	return ver.FieldErr(field, err)
 }
+
+// GinBind binds the request into a new T and validates it with
+// [ShouldBind].
+//
+//	params, err := verify.GinBind[SignUpParams](c)
+//	if err != nil {
+//	    v.GinAbort(c, err)
+//	    return
+//	}
+func GinBind[T any](c *gin.Context) (T, error) {
+	var obj T
+	err := ShouldBind(c, &obj)
+	return obj, err
+}
+
+// GinAbort aborts the request with 400 and the [ErrorResponse] for err.
+func (ver *Verifier) GinAbort(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, ver.Response(err))
+}
+
+// GinError is like [Verifier.GinAbort] with the Verifier of c (see
+// [GinVerifier]). Without one, the message is the error text.
+func GinError(c *gin.Context, err error) {
+	if ver := GinVerifier(c); ver != nil {
+		ver.GinAbort(c, err)
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+}
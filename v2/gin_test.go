@@ -0,0 +1,159 @@
+package verify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	verify "github.com/gtkit/verify/v2"
+)
+
+func init() { gin.SetMode(gin.TestMode) }
+
+func TestGinBind(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("zh"), verify.WithGinBinding())
+	r := gin.New()
+	r.POST("/signup", func(c *gin.Context) {
+		params, err := verify.GinBind[SignUpParams](c)
+		if err != nil {
+			v.GinAbort(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"name": params.Name})
+	})
+
+	cases := []struct {
+		body, field, message string
+		code                 int
+	}{
+		{`{"name":"alice","email":"a@b.com","password":"123456","re_password":"123456","age":20}`, "", "", 200},
+		{`{"name":"a","email":"a@b.com","password":"123456","re_password":"123456","age":20}`, "name", "name长度必须至少为2个字符", 400},
+		{`{"name":1}`, "name", "name类型不正确", 400},
+		{`{"name":`, "", "请求体格式错误", 400},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		if w.Code != tc.code {
+			t.Fatalf("%s: expected %d, got %d %s", tc.body, tc.code, w.Code, w.Body)
+		}
+		if tc.code == 200 {
+			continue
+		}
+		var resp verify.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Message != tc.message || tc.field != "" && resp.Errors[0].Field != tc.field {
+			t.Fatalf("%s: unexpected response %+v", tc.body, resp)
+		}
+	}
+}
+
+func TestGinMiddleware(t *testing.T) {
+	zh := verify.MustNew(verify.WithLocale("zh"))
+	en := verify.MustNew(verify.WithLocale("en"))
+	r := gin.New()
+	handler := func(c *gin.Context) {
+		var params SignUpParams
+		if err := verify.ShouldBind(c, &params); err != nil {
+			verify.GinError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"name": params.Name})
+	}
+	r.Group("/api", zh.GinMiddleware()).POST("/signup", handler)
+	r.Group("/admin", en.GinMiddleware()).POST("/signup", handler)
+
+	body := `{"name":"a","email":"a@b.com","password":"123456","re_password":"123456","age":20}`
+	for path, message := range map[string]string{
+		"/api/signup":   "name长度必须至少为2个字符",
+		"/admin/signup": "name must be at least 2 characters in length",
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		var resp verify.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != http.StatusBadRequest || resp.Message != message {
+			t.Fatalf("%s: unexpected response %d %+v", path, w.Code, resp)
+		}
+	}
+}
+
+func TestWithGinBinding_OverwriteWarning(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(prev)
+	defer func(v binding.StructValidator) { binding.Validator = v }(binding.Validator)
+	binding.Validator = nil
+
+	verify.MustNew(verify.WithLocale("zh"), verify.WithGinBinding())
+	if strings.Contains(buf.String(), "overwritten") {
+		t.Fatalf("unexpected warning: %s", buf.String())
+	}
+	verify.MustNew(verify.WithLocale("en"), verify.WithGinBinding())
+	if !strings.Contains(buf.String(), "binding.Validator overwritten") {
+		t.Fatalf("expected overwrite warning, got %q", buf.String())
+	}
+}
+
+func TestWithGinBinding_LogCaller(t *testing.T) {
+	defer func(v binding.StructValidator) { binding.Validator = v }(binding.Validator)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	verify.MustNew(verify.WithLogger(logger), verify.WithGinBinding())
+
+	var p LogSignup
+	if err := binding.JSON.BindBody([]byte(`{}`), &p); err == nil {
+		t.Fatal("expected violations")
+	}
+	var rec struct{ Caller string }
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if !strings.Contains(rec.Caller, "gin_test.go:") {
+		t.Fatalf("expected the test as caller, got %q", rec.Caller)
+	}
+}
+
+func TestGinMiddleware_KeepsGinValidator(t *testing.T) {
+	prev := binding.Validator
+	r := gin.New()
+	r.Use(verify.MustNew(verify.WithLocale("en")).GinMiddleware())
+	if binding.Validator != prev {
+		t.Fatal("GinMiddleware replaced binding.Validator")
+	}
+
+	var plain error
+	r.POST("/form", func(c *gin.Context) {
+		var params SignUpParams
+		plain = c.ShouldBind(&params)
+		if err := verify.ShouldBind(c, &params); err != nil {
+			verify.GinError(c, err)
+		}
+	})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("name=a"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ServeHTTP(w, req)
+	if plain == nil {
+		t.Fatal("expected Gin's validator to reject the plain bind")
+	}
+	var resp verify.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Errors[0].Field != "name" {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,252 @@
+// Package grpcx validates incoming gRPC request messages with a
+// [verify.Verifier].
+//
+//	v := verify.MustNew(verify.WithLocale("zh"))
+//	en := verify.MustNew(verify.WithLocale("en"))
+//	srv := grpc.NewServer(
+//	    grpc.ChainUnaryInterceptor(grpcx.UnaryServerInterceptor(v, grpcx.WithVerifiers(en))),
+//	    grpc.ChainStreamInterceptor(grpcx.StreamServerInterceptor(v, grpcx.WithVerifiers(en))),
+//	)
+//
+// Messages are validated through their struct tags ("binding" and the
+// other verify tags) and, if they have one, their Validate or ValidateAll
+// method. Failures are returned as codes.InvalidArgument with a
+// google.rpc.BadRequest detail listing the field violations, localized
+// for the locale requested in the "accept-language" metadata. The messages
+// of Validate and ValidateAll errors come from the message itself and are
+// passed through untranslated.
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	verify "github.com/gtkit/verify/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Option configures the interceptors.
+type Option func(*options)
+
+type options struct {
+	verifiers map[string]*verify.Verifier
+	localeKey string
+}
+
+// WithVerifiers adds Verifiers selectable by their locale.
+func WithVerifiers(vs ...*verify.Verifier) Option {
+	return func(o *options) {
+		for _, v := range vs {
+			o.verifiers[v.Locale()] = v
+		}
+	}
+}
+
+// WithLocaleKey sets the metadata key carrying the requested locale, in
+// Accept-Language syntax. Default: "accept-language".
+func WithLocaleKey(key string) Option {
+	return func(o *options) { o.localeKey = strings.ToLower(key) }
+}
+
+// UnaryServerInterceptor validates unary request messages.
+func UnaryServerInterceptor(v *verify.Verifier, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(v, opts)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := o.validate(ctx, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream.
+func StreamServerInterceptor(v *verify.Verifier, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(v, opts)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, opts: o})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	opts *options
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.opts.validate(s.Context(), m)
+}
+
+func newOptions(v *verify.Verifier, opts []Option) *options {
+	o := &options{verifiers: map[string]*verify.Verifier{"": v}, localeKey: "accept-language"}
+	o.verifiers[v.Locale()] = v
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// validate returns nil or a status error for msg.
+func (o *options) validate(ctx context.Context, msg any) error {
+	ver := o.verifier(ctx)
+	var violations []verify.FieldViolation
+
+	if isStruct(msg) {
+		err := ver.StructCtx(ctx, msg)
+		errs := ver.Errors(err)
+		switch {
+		case errs != nil:
+			violations = errs.Violations
+		case err != nil:
+			return toStatus(err)
+		}
+	}
+	if err := selfValidate(msg); err != nil {
+		var errs *verify.Errors
+		if errors.As(err, &errs) {
+			violations = append(violations, errs.Violations...)
+		} else {
+			violations = append(violations, hookViolations(err)...)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return InvalidArgument(&verify.Errors{Violations: violations})
+}
+
+// verifier picks the Verifier for the locale requested in ctx.
+func (o *options) verifier(ctx context.Context) *verify.Verifier {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(o.localeKey) {
+		for _, tag := range parseAcceptLanguage(value) {
+			if v, ok := o.verifiers[tag]; ok {
+				return v
+			}
+			base, _, _ := strings.Cut(tag, "-")
+			if v, ok := o.verifiers[base]; ok {
+				return v
+			}
+		}
+	}
+	return o.verifiers[""]
+}
+
+// InvalidArgument converts violations into a codes.InvalidArgument status
+// error with a google.rpc.BadRequest detail. Without violations, such as
+// for a nil errs, the status has no detail.
+func InvalidArgument(errs *verify.Errors) error {
+	if errs.Len() == 0 {
+		msg := errs.Error()
+		if msg == "" {
+			msg = "invalid argument"
+		}
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	br := &errdetails.BadRequest{}
+	for _, fv := range errs.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fv.Field,
+			Description: fv.Message,
+			Reason:      fv.Tag,
+		})
+	}
+	st := status.New(codes.InvalidArgument, errs.Error())
+	if withDetails, err := st.WithDetails(br); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func toStatus(err error) error {
+	if st := status.FromContextError(err); st.Code() != codes.Unknown {
+		return st.Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func isStruct(msg any) bool {
+	t := reflect.TypeOf(msg)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// selfValidate calls the message's ValidateAll or Validate method, as
+// generated by protoc-gen-validate.
+func selfValidate(msg any) error {
+	switch m := msg.(type) {
+	case interface{ ValidateAll() error }:
+		return m.ValidateAll()
+	case interface{ Validate() error }:
+		return m.Validate()
+	}
+	return nil
+}
+
+// hookViolations converts an error returned by a Validate hook, unwrapping
+// protoc-gen-validate multi errors and field errors. Their messages are
+// kept as they are: verify has no translation for them.
+func hookViolations(err error) []verify.FieldViolation {
+	if multi, ok := err.(interface{ AllErrors() []error }); ok {
+		var out []verify.FieldViolation
+		for _, e := range multi.AllErrors() {
+			out = append(out, hookViolations(e)...)
+		}
+		return out
+	}
+	if fe, ok := err.(interface {
+		Field() string
+		Reason() string
+	}); ok {
+		return []verify.FieldViolation{{Field: fe.Field(), Tag: "validate", Message: fe.Reason()}}
+	}
+	return []verify.FieldViolation{{Tag: "validate", Message: err.Error()}}
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language
+// value ordered by preference, lower-cased.
+func parseAcceptLanguage(value string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for part := range strings.SplitSeq(value, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if qv, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(qv, 64); err == nil {
+				q = f
+			}
+		}
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" && q > 0 {
+			tags = append(tags, weighted{tag, q})
+		}
+	}
+	slices.SortStableFunc(tags, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
@@ -0,0 +1,196 @@
+package grpcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/grpcx"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// jsonCodec lets the test exchange plain Go structs instead of generated
+// protobuf messages.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return "json" }
+
+type CreateUserRequest struct {
+	Name  string `json:"name" binding:"required,min=2"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+type CreateUserReply struct {
+	OK bool `json:"ok"`
+}
+
+// PingRequest validates itself through a Validate hook.
+type PingRequest struct {
+	Text string `json:"text"`
+}
+
+func (p *PingRequest) Validate() error {
+	if p.Text != "ping" {
+		return errors.New("text must be ping")
+	}
+	return nil
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Users",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Create", Handler: unaryHandler(new(CreateUserRequest))},
+		{MethodName: "Ping", Handler: unaryHandler(new(PingRequest))},
+	},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Import",
+		ClientStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			for {
+				var req CreateUserRequest
+				if err := stream.RecvMsg(&req); err != nil {
+					if errors.Is(err, io.EOF) {
+						return stream.SendMsg(&CreateUserReply{OK: true})
+					}
+					return err
+				}
+			}
+		},
+	}},
+}
+
+func unaryHandler(req any) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		handler := func(context.Context, any) (any, error) { return &CreateUserReply{OK: true}, nil }
+		return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv}, handler)
+	}
+}
+
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	zh := verify.MustNew(verify.WithLocale("zh"))
+	en := verify.MustNew(verify.WithLocale("en"))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(jsonCodec{}),
+		grpc.UnaryInterceptor(grpcx.UnaryServerInterceptor(zh, grpcx.WithVerifiers(en))),
+		grpc.StreamInterceptor(grpcx.StreamServerInterceptor(zh, grpcx.WithVerifiers(en))),
+	)
+	srv.RegisterService(&serviceDesc, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func badRequest(t *testing.T, err error) (*status.Status, *errdetails.BadRequest) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			return st, br
+		}
+	}
+	t.Fatalf("missing BadRequest detail in %v", st.Details())
+	return nil, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+
+	var reply CreateUserReply
+	if err := conn.Invoke(ctx, "/test.Users/Create", &CreateUserRequest{Name: "alice", Email: "a@b.com"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.OK {
+		t.Fatal("expected handler reply")
+	}
+
+	err := conn.Invoke(ctx, "/test.Users/Create", &CreateUserRequest{Name: "a", Email: "bad"}, &reply)
+	_, br := badRequest(t, err)
+	if len(br.FieldViolations) != 2 || br.FieldViolations[0].Field != "name" || br.FieldViolations[0].Reason != "min" {
+		t.Fatalf("unexpected violations %v", br.FieldViolations)
+	}
+	if br.FieldViolations[0].Description != "name长度必须至少为2个字符" {
+		t.Fatalf("expected zh description, got %q", br.FieldViolations[0].Description)
+	}
+
+	enCtx := metadata.AppendToOutgoingContext(ctx, "accept-language", "fr;q=0.9, en-US")
+	err = conn.Invoke(enCtx, "/test.Users/Create", &CreateUserRequest{Name: "a", Email: "a@b.com"}, &reply)
+	if _, br := badRequest(t, err); br.FieldViolations[0].Description != "name must be at least 2 characters in length" {
+		t.Fatalf("expected en description, got %q", br.FieldViolations[0].Description)
+	}
+}
+
+func TestUnaryServerInterceptor_ValidateHook(t *testing.T) {
+	conn := dial(t)
+	var reply CreateUserReply
+	if err := conn.Invoke(context.Background(), "/test.Users/Ping", &PingRequest{Text: "ping"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	err := conn.Invoke(context.Background(), "/test.Users/Ping", &PingRequest{Text: "pong"}, &reply)
+	st, br := badRequest(t, err)
+	if st.Message() != "text must be ping" || br.FieldViolations[0].Reason != "validate" {
+		t.Fatalf("unexpected status %v %v", st, br)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	conn := dial(t)
+	desc := &grpc.StreamDesc{StreamName: "Import", ClientStreams: true}
+	stream, err := conn.NewStream(context.Background(), desc, "/test.Users/Import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendMsg(&CreateUserRequest{Name: "alice", Email: "a@b.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendMsg(&CreateUserRequest{Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var reply CreateUserReply
+	_, br := badRequest(t, stream.RecvMsg(&reply))
+	if br.FieldViolations[0].Field != "email" {
+		t.Fatalf("unexpected violations %v", br.FieldViolations)
+	}
+}
+
+func TestInvalidArgument_NoViolations(t *testing.T) {
+	st := status.Convert(grpcx.InvalidArgument(nil))
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 0 {
+		t.Fatalf("unexpected status %v", st)
+	}
+}
@@ -0,0 +1,87 @@
+// Package hertzx installs a [verify.Verifier] as the validator of a
+// CloudWeGo Hertz server and renders bind and validation errors.
+//
+//	v := verify.MustNew(verify.WithLocale("zh"))
+//	h := server.Default(server.WithCustomValidatorFunc(hertzx.NewValidator(v)))
+//	h.Use(hertzx.Middleware(v))
+//
+//	h.POST("/signup", func(ctx context.Context, c *app.RequestContext) {
+//	    params, err := hertzx.Bind[SignUpParams](c)
+//	    if err != nil {
+//	        hertzx.Error(c, err)
+//	        return
+//	    }
+//	    c.JSON(consts.StatusOK, params)
+//	})
+//
+// Hertz does not expose its validator to handlers, so [Middleware] stores
+// the Verifier in the request context for [Error].
+package hertzx
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server/binding"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	verify "github.com/gtkit/verify/v2"
+)
+
+// verifierKey is the request context key holding the Verifier.
+const verifierKey = "github.com/gtkit/verify/v2/hertzx.verifier"
+
+// NewValidator returns a Hertz validator function backed by ver, for use
+// with server.WithCustomValidatorFunc.
+func NewValidator(ver *verify.Verifier) binding.ValidatorFunc {
+	return func(_ *protocol.Request, obj any) error {
+		return ver.Struct(obj)
+	}
+}
+
+// Middleware stores ver in the request context for [Error].
+func Middleware(ver *verify.Verifier) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		c.Set(verifierKey, ver)
+		c.Next(ctx)
+	}
+}
+
+// Bind binds the request into a new T and validates it with the server's
+// validator function. Hertz flattens body decode errors into text, so a
+// JSON body is decoded with encoding/json, keeping the typed error for
+// [Error], and only the path, query and header tags are bound by Hertz.
+// Other requests go through c.BindAndValidate.
+func Bind[T any](c *app.RequestContext) (T, error) {
+	var obj T
+	if len(c.Request.Body()) == 0 ||
+		!strings.HasPrefix(string(c.Request.Header.ContentType()), consts.MIMEApplicationJSON) {
+		return obj, c.BindAndValidate(&obj)
+	}
+	if err := json.Unmarshal(c.Request.Body(), &obj); err != nil {
+		return obj, err
+	}
+	for _, bind := range []func(any) error{c.BindPath, c.BindQuery, c.BindHeader} {
+		if err := bind(&obj); err != nil {
+			return obj, err
+		}
+	}
+	return obj, c.Validate(&obj)
+}
+
+// Error aborts the request with 400 and the [verify.ErrorResponse] for
+// err, localized by the Verifier stored by [Middleware] or else the
+// package-level default.
+func Error(c *app.RequestContext, err error) {
+	ver, _ := c.Value(verifierKey).(*verify.Verifier)
+	if ver == nil {
+		ver = verify.Default()
+	}
+	if ver == nil {
+		c.AbortWithStatusJSON(consts.StatusBadRequest, verify.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.AbortWithStatusJSON(consts.StatusBadRequest, ver.Response(err))
+}
@@ -0,0 +1,90 @@
+package hertzx_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/hertzx"
+)
+
+type SignUpParams struct {
+	Name  string `json:"name" binding:"required,min=2"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+func TestBind(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("zh"))
+	h := server.New(server.WithCustomValidatorFunc(hertzx.NewValidator(v)))
+	h.Use(hertzx.Middleware(v))
+	h.POST("/signup", func(_ context.Context, c *app.RequestContext) {
+		params, err := hertzx.Bind[SignUpParams](c)
+		if err != nil {
+			hertzx.Error(c, err)
+			return
+		}
+		c.JSON(consts.StatusOK, params)
+	})
+
+	cases := []struct {
+		body, field, message string
+		code                 int
+	}{
+		{`{"name":"alice","email":"a@b.com"}`, "", "", 200},
+		{`{"name":"a","email":"a@b.com"}`, "name", "name长度必须至少为2个字符", 400},
+		{`{"name":1}`, "name", "name类型不正确", 400},
+		{`{"name":`, "", "请求体格式错误", 400},
+	}
+	for _, tc := range cases {
+		w := ut.PerformRequest(h.Engine, consts.MethodPost, "/signup",
+			&ut.Body{Body: strings.NewReader(tc.body), Len: len(tc.body)},
+			ut.Header{Key: consts.HeaderContentType, Value: consts.MIMEApplicationJSON})
+		res := w.Result()
+		if res.StatusCode() != tc.code {
+			t.Fatalf("%s: expected %d, got %d %s", tc.body, tc.code, res.StatusCode(), res.Body())
+		}
+		if tc.code == 200 {
+			continue
+		}
+		var resp verify.ErrorResponse
+		if err := json.Unmarshal(res.Body(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Message != tc.message || tc.field != "" && resp.Errors[0].Field != tc.field {
+			t.Fatalf("%s: unexpected response %+v", tc.body, resp)
+		}
+	}
+}
+
+type InviteParams struct {
+	Team  string `path:"team" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+func TestBind_PathAndBody(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("zh"))
+	h := server.New(server.WithCustomValidatorFunc(hertzx.NewValidator(v)))
+	h.POST("/teams/:team/invites", func(_ context.Context, c *app.RequestContext) {
+		params, err := hertzx.Bind[InviteParams](c)
+		if err != nil {
+			hertzx.Error(c, err)
+			return
+		}
+		c.JSON(consts.StatusOK, params)
+	})
+
+	body := `{"email":"a@b.com"}`
+	w := ut.PerformRequest(h.Engine, consts.MethodPost, "/teams/core/invites",
+		&ut.Body{Body: strings.NewReader(body), Len: len(body)},
+		ut.Header{Key: consts.HeaderContentType, Value: consts.MIMEApplicationJSON})
+	var got InviteParams
+	if err := json.Unmarshal(w.Result().Body(), &got); err != nil || got.Team != "core" || got.Email != "a@b.com" {
+		t.Fatalf("unexpected response %d %s", w.Result().StatusCode(), w.Result().Body())
+	}
+}
@@ -0,0 +1,204 @@
+// Package env sets the fields of structs from environment variables. It is
+// shared by verify.BindEnv and config.LoadEnv.
+package env
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decoder holds what [Decode] found.
+type Decoder struct {
+	Vars     map[string]string // Go field namespace → variable
+	Failed   map[string]bool   // Go field namespaces that failed to parse
+	Failures []Failure
+
+	active map[reflect.Type]bool // struct types being decoded
+}
+
+// Failure is a variable whose value could not be parsed.
+type Failure struct {
+	Name     string // variable
+	StructNs string // Go field namespace
+	Raw      string
+	Field    reflect.StructField
+	Err      error
+}
+
+// Decode sets the fields of the struct v from environment variables.
+//
+// A field reads the variable prefix + its "env" tag, or its "envDefault"
+// tag when the variable is unset. A struct field with an "env" tag adds
+// NAME_ to the prefix of its fields; without one its fields share the
+// prefix. A nil pointer to a struct is only allocated when a variable or
+// default sets one of its fields, and a struct type is not entered again
+// below itself. Supported are strings, booleans, numbers, time.Duration,
+// encoding.TextUnmarshaler, pointers to them and slices of them, split on
+// "," or the "envSeparator" tag.
+func Decode(v reflect.Value, prefix string) *Decoder {
+	d := &Decoder{Vars: map[string]string{}, Failed: map[string]bool{}, active: map[reflect.Type]bool{}}
+	d.decode(v, prefix, "")
+	return d
+}
+
+// VarOf returns the variable of the field at structNs, with the index
+// of slice elements appended: "Hosts[1]" → "APP_HOSTS[1]".
+func (d *Decoder) VarOf(structNs string) (string, bool) {
+	if name, ok := d.Vars[structNs]; ok {
+		return name, true
+	}
+	if i := strings.IndexByte(structNs, '['); i > 0 {
+		if name, ok := d.Vars[structNs[:i]]; ok {
+			return name + structNs[i:], true
+		}
+	}
+	return "", false
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// decode sets the fields of v and reports whether it set any.
+func (d *Decoder) decode(v reflect.Value, prefix, ns string) bool {
+	t := v.Type()
+	d.active[t] = true
+	defer delete(d.active, t)
+	set := false
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		name := fld.Tag.Get("env")
+		if !fld.IsExported() || name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		fns := fld.Name
+		if ns != "" {
+			fns = ns + "." + fld.Name
+		}
+
+		if st := fld.Type; isStruct(st) || st.Kind() == reflect.Pointer && isStruct(st.Elem()) {
+			sub := prefix
+			if name != "" {
+				sub += name + "_"
+			}
+			switch {
+			case fv.Kind() != reflect.Pointer:
+				set = d.decode(fv, sub, fns) || set
+			case d.active[st.Elem()]:
+				// A recursive type, such as a linked list, ends here.
+			case !fv.IsNil():
+				set = d.decode(fv.Elem(), sub, fns) || set
+			default:
+				// An optional section stays nil unless configured.
+				elem := reflect.New(st.Elem())
+				if d.decode(elem.Elem(), sub, fns) {
+					fv.Set(elem)
+					set = true
+				}
+			}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		d.Vars[fns] = key
+		raw, ok := os.LookupEnv(key)
+		if !ok {
+			if raw, ok = fld.Tag.Lookup("envDefault"); !ok {
+				continue
+			}
+		}
+		sep := fld.Tag.Get("envSeparator")
+		if sep == "" {
+			sep = ","
+		}
+		set = true
+		if err := SetValue(fv, raw, sep); err != nil {
+			d.Failed[fns] = true
+			d.Failures = append(d.Failures, Failure{Name: key, StructNs: fns, Raw: raw, Field: fld, Err: err})
+		}
+	}
+	return set
+}
+
+// isStruct reports whether t is a struct whose fields are read from their
+// own variables rather than one variable.
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// SetValue parses raw into v, splitting slices on sep.
+func SetValue(v reflect.Value, raw, sep string) error {
+	switch {
+	case v.Kind() == reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := SetValue(elem.Elem(), raw, sep); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case v.Addr().Type().Implements(textUnmarshalerType):
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	case v.Type() == durationType:
+		dur, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(dur))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if raw == "" {
+			return nil
+		}
+		parts := strings.Split(raw, sep)
+		s := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := SetValue(s.Index(i), strings.TrimSpace(part), sep); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		v.Set(s)
+	default:
+		return errors.New("unsupported type " + v.Type().String())
+	}
+	return nil
+}
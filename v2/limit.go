@@ -0,0 +1,233 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// WithFailFast makes [Verifier.Struct] stop at the first violation, like
+// WithMaxErrors(1).
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithMaxErrors makes [Verifier.Struct] stop once n violations were found
+// and return only those. The struct elements of top-level slices ending in
+// "dive" are validated one at a time after the other fields, so the rest
+// of a large slice is neither traversed nor translated. Default: 0, no
+// limit.
+func WithMaxErrors(n int) Option {
+	return func(c *config) { c.maxErrors = n }
+}
+
+type maxErrorsKey struct{}
+
+// FailFast returns a copy of ctx that makes [Verifier.StructCtx] stop at the
+// first violation, whatever the limit of the Verifier.
+//
+//	err := v.StructCtx(verify.FailFast(ctx), order)
+func FailFast(ctx context.Context) context.Context {
+	return MaxErrors(ctx, 1)
+}
+
+// MaxErrors returns a copy of ctx that makes [Verifier.StructCtx] stop once
+// n violations were found, see [WithMaxErrors]. n = 0 lifts the limit of
+// the Verifier.
+func MaxErrors(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, maxErrorsKey{}, n)
+}
+
+// maxErrorsFor returns the limit for a call with ctx; 0 means none.
+func (ver *Verifier) maxErrorsFor(ctx context.Context) int {
+	if n, ok := ctx.Value(maxErrorsKey{}).(int); ok {
+		return max(n, 0)
+	}
+	return ver.maxErrors
+}
+
+// limitReached reports whether err holds at least limit violations.
+func limitReached(err error, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	return ok && len(valErrs) >= limit
+}
+
+// truncateErrors keeps the first limit violations of err.
+func truncateErrors(err error, limit int) error {
+	if valErrs, ok := errors.AsType[validator.ValidationErrors](err); ok && limit > 0 && len(valErrs) > limit {
+		return valErrs[:limit]
+	}
+	return err
+}
+
+// diveField is a top-level field of a struct whose struct elements are
+// validated one at a time.
+type diveField struct {
+	index int
+	path  string // Go namespace of the field, e.g. "Order.Items"
+	name  string // namespace of the field, e.g. "Order.items"
+}
+
+// structLimited validates the tags of s like [validator.Validate.StructCtx]
+// but stops once limit violations were found. Only the struct elements of
+// the top-level fields ending in "dive" are validated on their own, one
+// element at a time, after everything else in one pass; their violations
+// come last.
+func (ver *Verifier) structLimited(ctx context.Context, s any, limit int) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || rv.Type() == timeType {
+		return ver.validate.StructCtx(ctx, s) // reports invalid arguments
+	}
+	t := rv.Type()
+	prefix := ""
+	if t.Name() != "" {
+		prefix = t.Name() + "."
+	}
+
+	var dives []diveField
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if fv := indirect(rv.Field(i)); fv.IsValid() && fv.CanInterface() && ver.divesLast(fld) {
+			name := fld.Name
+			if alt := ver.tagNameFunc(fld); alt != "" {
+				name = alt
+			}
+			dives = append(dives, diveField{index: i, path: prefix + fld.Name, name: prefix + name})
+		}
+	}
+	if len(dives) == 0 {
+		return ver.validate.StructCtx(ctx, s)
+	}
+
+	// The fields of the elements are skipped, but the elements are still
+	// reached by dive once the tags of their slice passed.
+	under := func(ns []byte) (diveField, bool) {
+		for _, d := range dives {
+			if len(ns) > len(d.path) && ns[len(d.path)] == '[' && string(ns[:len(d.path)]) == d.path {
+				return d, true
+			}
+		}
+		return diveField{}, false
+	}
+	err := ver.validate.StructFilteredCtx(ctx, s, func(ns []byte) bool {
+		_, ok := under(ns)
+		return ok
+	})
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if err != nil && !ok {
+		return err
+	}
+	out := make(validator.ValidationErrors, 0, len(valErrs))
+	failed := map[string]bool{}
+	for _, fe := range valErrs {
+		if _, ok := under([]byte(fe.StructNamespace())); ok {
+			// Reported by a struct-level validation of an element that
+			// was registered on the engine directly; it runs again below.
+			continue
+		}
+		failed[fe.StructNamespace()] = true
+		out = append(out, fe)
+	}
+
+	for _, d := range dives {
+		if len(out) >= limit {
+			return out
+		}
+		if failed[d.path] {
+			continue // dive is not reached
+		}
+		fv := indirect(rv.Field(d.index))
+		for j := range fv.Len() {
+			elem := fv.Index(j)
+			if indirect(elem).Kind() != reflect.Struct {
+				continue // nil elements are skipped by dive
+			}
+			err := ver.validate.StructCtx(ctx, elem.Interface())
+			valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+			if err != nil && !ok {
+				return err
+			}
+			index := "[" + strconv.Itoa(j) + "]"
+			for _, fe := range valErrs {
+				out = append(out, reroot(fe, indirect(elem).Type().Name(), d.name+index, d.path+index))
+			}
+			if len(out) >= limit {
+				return out
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// divesLast reports whether the tags of fld end with a dive into elements
+// that are structs validated independently of the struct holding them and
+// without struct-level validations, which would run in both passes.
+func (ver *Verifier) divesLast(fld reflect.StructField) bool {
+	tags := strings.Split(fld.Tag.Get("binding"), ",")
+	if tags[len(tags)-1] != "dive" || slices.Contains(tags, "keys") {
+		return false
+	}
+	t := fld.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	e := t.Elem()
+	for e.Kind() == reflect.Pointer {
+		e = e.Elem()
+	}
+	if e.Kind() != reflect.Struct || e == timeType {
+		return false
+	}
+	if _, ok := ver.structLevels.Load(e); ok {
+		return false
+	}
+	return !hasCrossStructTag(e, map[reflect.Type]bool{})
+}
+
+// hasCrossStructTag reports whether a field inside t compares itself with
+// a field outside t, such as eqcsfield=Order.ID.
+func hasCrossStructTag(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if strings.Contains(fld.Tag.Get("binding"), "csfield") || hasCrossStructTag(fld.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// reroot replaces top, the element type name leading the namespaces of fe,
+// with ns and structNs, e.g. "Item.sku" → "Order.items[3].sku".
+func reroot(fe validator.FieldError, top, ns, structNs string) validator.FieldError {
+	trim := func(s string) string {
+		if top == "" {
+			return "." + s
+		}
+		return strings.TrimPrefix(s, top)
+	}
+	return &wrappedError{FieldError: fe, ns: ns + trim(fe.Namespace()), structNs: structNs + trim(fe.StructNamespace())}
+}
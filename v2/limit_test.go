@@ -0,0 +1,144 @@
+package verify_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type LimitItem struct {
+	SKU string `json:"sku" binding:"required"`
+	Qty int    `json:"qty" binding:"min=1"`
+}
+
+type LimitOrder struct {
+	ID    string       `json:"id" binding:"required"`
+	Items []*LimitItem `json:"items" binding:"required,max=20000,dive"`
+	Note  string       `json:"note" binding:"max=5"`
+}
+
+func limitOrder(n int) *LimitOrder {
+	o := &LimitOrder{Note: "too long"}
+	for range n {
+		o.Items = append(o.Items, &LimitItem{})
+	}
+	return o
+}
+
+func TestFailFast(t *testing.T) {
+	v := verify.MustNew(verify.WithFailFast())
+	errs := v.Errors(v.Struct(limitOrder(100)))
+	if errs.Len() != 1 || errs.Violations[0].Field != "id" {
+		t.Fatalf("expected only id, got %v", errs.Map())
+	}
+}
+
+func TestMaxErrors(t *testing.T) {
+	v := verify.MustNew(verify.WithMaxErrors(4))
+	o := limitOrder(100)
+	o.ID = "o-1"
+	errs := v.Errors(v.Struct(o))
+	var fields []string
+	for _, fv := range errs.Violations {
+		fields = append(fields, fv.Field)
+	}
+	// The elements of items come after the other fields.
+	if got := fmt.Sprint(fields); got != "[note items[0].sku items[0].qty items[1].sku]" {
+		t.Fatalf("unexpected violations %s", got)
+	}
+	if fv := errs.Violations[1]; fv.StructField != "Items[0].SKU" || fv.Message != "sku为必填字段" {
+		t.Fatalf("unexpected first violation %+v", fv)
+	}
+
+	// Below the limit the result is the same as without one.
+	o.Items = o.Items[:1]
+	got := v.AllFieldErrors(v.Struct(o))
+	all := verify.MustNew()
+	want := all.AllFieldErrors(all.Struct(o))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMaxErrors_Context(t *testing.T) {
+	v := verify.MustNew(verify.WithMaxErrors(2))
+	o := limitOrder(10)
+
+	if errs := v.Errors(v.StructCtx(verify.FailFast(context.Background()), o)); errs.Len() != 1 {
+		t.Fatalf("expected 1 violation, got %v", errs.Map())
+	}
+	if errs := v.Errors(v.StructCtx(verify.MaxErrors(context.Background(), 0), o)); errs.Len() != 22 {
+		t.Fatalf("expected all 22 violations, got %d", errs.Len())
+	}
+	if _, err := verify.New(verify.WithMaxErrors(-1)); err == nil {
+		t.Fatal("expected error for negative max errors")
+	}
+}
+
+func TestMaxErrors_StructLevel(t *testing.T) {
+	v := verify.MustNew(verify.WithMaxErrors(10))
+	calls := 0
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		calls++
+		if o := sl.Current().Interface().(LimitOrder); o.ID == "" {
+			sl.ReportError(o.ID, "id", "ID", "id_or_items", "")
+		}
+	}, LimitOrder{})
+
+	errs := v.Errors(v.Struct(&LimitOrder{Items: []*LimitItem{{SKU: "a", Qty: 1}}}))
+	if errs.Len() != 2 || errs.Violations[0].Tag != "required" || errs.Violations[1].Tag != "id_or_items" {
+		t.Fatalf("expected required and the struct-level violation once, got %+v", errs.Violations)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the struct-level validation to run once, ran %d times", calls)
+	}
+}
+
+func BenchmarkStruct_MaxErrors(b *testing.B) {
+	o := limitOrder(10000)
+	o.ID = "o-1"
+	for _, bc := range []struct {
+		name string
+		v    *verify.Verifier
+	}{
+		{"all", verify.MustNew()},
+		{"max_errors_10", verify.MustNew(verify.WithMaxErrors(10))},
+		{"fail_fast", verify.MustNew(verify.WithFailFast())},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if bc.v.Errors(bc.v.Struct(o)) == nil {
+					b.Fatal("expected violations")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStruct_MaxErrorsValid(b *testing.B) {
+	o := &LimitOrder{ID: "o-1"}
+	for range 100 {
+		o.Items = append(o.Items, &LimitItem{SKU: "a", Qty: 1})
+	}
+	for _, bc := range []struct {
+		name string
+		v    *verify.Verifier
+	}{
+		{"all", verify.MustNew()},
+		{"fail_fast", verify.MustNew(verify.WithFailFast())},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := bc.v.Struct(o); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
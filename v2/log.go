@@ -0,0 +1,133 @@
+package verify
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithLogger logs the failed validations of [Verifier.StructCtx] and the
+// helpers built on it at debug level: the validated type, the number of
+// violations, the violations themselves (see [Errors.LogValue]) and the
+// caller outside verify. Default: nil, no logging.
+//
+//	verify.WithLogger(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})))
+func WithLogger(l *slog.Logger) Option {
+	return func(c *config) { c.logger = l }
+}
+
+// LogValue groups the path, tag, code, param, value and message of fv.
+// The value is the redacted one for fields tagged "redact" or "sensitive".
+func (fv FieldViolation) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 7)
+	attrs = append(attrs, slog.String("path", fv.Field), slog.String("tag", fv.Tag), slog.String("code", fv.Code))
+	if fv.Param != "" {
+		attrs = append(attrs, slog.String("param", fv.Param))
+	}
+	attrs = append(attrs, slog.Any("value", fv.Value), slog.String("message", fv.Message))
+	if fv.Warning {
+		attrs = append(attrs, slog.Bool("warning", true))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue groups the number of violations and the violations and warnings
+// of e, each under its index:
+//
+//	{"count":2,"violations":{"0":{"path":"name","tag":"required",...},"1":{...}}}
+func (e *Errors) LogValue() slog.Value {
+	if e == nil {
+		return slog.GroupValue()
+	}
+	attrs := []slog.Attr{slog.Int("count", len(e.Violations))}
+	if len(e.Violations) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "violations", Value: violationsValue(e.Violations)})
+	}
+	if len(e.Warnings) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "warnings", Value: violationsValue(e.Warnings)})
+	}
+	if len(e.Violations) == 0 && e.cause != nil {
+		attrs = append(attrs, slog.String("cause", e.cause.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func violationsValue(fvs []FieldViolation) slog.Value {
+	attrs := make([]slog.Attr, len(fvs))
+	for i, fv := range fvs {
+		attrs[i] = slog.Any(strconv.Itoa(i), fv)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// pkgPath is the import path of this package, "github.com/gtkit/verify/v2".
+var pkgPath = strings.TrimSuffix(runtime.FuncForPC(reflect.ValueOf(New).Pointer()).Name(), ".New")
+
+// logEnabled reports whether failed validations are logged, see
+// [WithLogger].
+func (ver *Verifier) logEnabled(ctx context.Context) bool {
+	return ver.logger != nil && ver.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logFailure logs the failed validation of s with err, translated into
+// errs, see [WithLogger].
+func (ver *Verifier) logFailure(ctx context.Context, s any, err error, errs *Errors) {
+	if err == nil || !ver.logEnabled(ctx) {
+		return
+	}
+	pc, caller := callerOutside()
+
+	if errs == nil {
+		errs = &Errors{cause: err}
+	}
+	r := slog.NewRecord(time.Now(), slog.LevelDebug, "verify: validation failed", pc)
+	r.AddAttrs(slog.String("type", validatedType(s)), slog.Int("count", errs.Len()), slog.String("caller", caller), slog.Any("errors", errs))
+	_ = ver.logger.Handler().Handle(ctx, r)
+}
+
+// callerOutside returns the program counter and the "file:line" of the
+// first caller outside verify, its subpackages and the gin binding.
+func callerOutside() (uintptr, string) {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	for _, pc := range pcs[:n] {
+		// A pc may hold functions of this package inlined in the caller.
+		frames := runtime.CallersFrames([]uintptr{pc})
+		for {
+			f, more := frames.Next()
+			if !internalFrame(f.Function) {
+				return pc, f.File + ":" + strconv.Itoa(f.Line)
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return 0, ""
+}
+
+// internalFrame reports whether the function fn, as named by
+// [runtime.Frame], belongs to verify, one of its subpackages or the gin
+// binding validating through it. Tests of the subpackages do not.
+func internalFrame(fn string) bool {
+	if i := strings.IndexByte(fn, '['); i >= 0 {
+		fn = fn[:i] // type arguments may hold import paths
+	}
+	pkg := fn
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		if j := strings.IndexByte(fn[i:], '.'); j >= 0 {
+			pkg = fn[:i+j]
+		}
+	}
+	switch {
+	case pkg == pkgPath, pkg == "github.com/gin-gonic/gin/binding":
+		return true
+	case strings.HasPrefix(pkg, pkgPath+"/"):
+		return !strings.HasSuffix(pkg, "_test")
+	}
+	return false
+}
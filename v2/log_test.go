@@ -0,0 +1,82 @@
+package verify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type LogSignup struct {
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"min=8" sensitive:""`
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug}))
+	v := verify.MustNew(verify.WithLogger(logger))
+
+	if err := v.Struct(&LogSignup{Name: "alice", Password: "correct horse"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged for a valid struct, got %s", buf.String())
+	}
+
+	if err := v.Struct(&LogSignup{Password: "hunter2"}); err == nil {
+		t.Fatal("expected violations")
+	}
+	var rec struct {
+		Level  string
+		Msg    string
+		Type   string
+		Count  int
+		Caller string
+		Source struct{ File string }
+		Errors struct {
+			Count      int
+			Violations map[string]map[string]any
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if rec.Level != "DEBUG" || rec.Type != "verify_test.LogSignup" || rec.Count != 2 || rec.Errors.Count != 2 {
+		t.Fatalf("unexpected record %s", buf.String())
+	}
+	if !strings.Contains(rec.Caller, "log_test.go:") || !strings.HasSuffix(rec.Source.File, "log_test.go") {
+		t.Fatalf("expected the test as caller, got %q and %q", rec.Caller, rec.Source.File)
+	}
+	for _, fv := range rec.Errors.Violations {
+		if fv["path"] == "password" && (fv["value"] != "***" || fv["tag"] != "min" || fv["param"] != "8") {
+			t.Fatalf("unexpected password violation %v", fv)
+		}
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("sensitive value logged: %s", buf.String())
+	}
+
+	// Above debug level nothing is logged.
+	buf.Reset()
+	quiet := verify.MustNew(verify.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	_ = quiet.Struct(&LogSignup{})
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged at info level, got %s", buf.String())
+	}
+}
+
+func TestErrors_LogValue(t *testing.T) {
+	v := verify.MustNew()
+	errs := v.Errors(v.Struct(&LogSignup{Name: "alice", Password: "hunter2"}))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("invalid signup", "errors", errs)
+	want := "errors.count=1 errors.violations.0.path=password errors.violations.0.tag=min errors.violations.0.code=FIELD_MIN errors.violations.0.param=8 errors.violations.0.value=***"
+	if !strings.Contains(buf.String(), want) {
+		t.Fatalf("expected %q in %s", want, buf.String())
+	}
+}
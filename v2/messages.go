@@ -0,0 +1,362 @@
+package verify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// LoadMessages loads message bundles matching pattern from fsys and makes
+// them override the default translations. It replaces any previously
+// loaded bundle atomically, so in-flight validations are never blocked.
+//
+// A bundle is a JSON, YAML or TOML file. Its locale comes from the
+// "locale" key or from the file name ("zh.yaml", "messages.en.json");
+// bundles for other locales are skipped.
+//
+//	locale: zh
+//	tags:
+//	  required: "{0}不能为空"
+//	  max:
+//	    one: "{0}最多{1}项"
+//	    other: "{0}最多{1}项"
+//	fields:
+//	  SignUpParams.name:
+//	    required: "请填写用户名"
+//
+// {0} is the field name and {1} the tag parameter. Plural forms
+// (zero/one/two/few/many/other) are chosen from the tag parameter using
+// the locale's cardinal plural rules. Field keys are namespaces with the
+// slice indexes removed.
+func (ver *Verifier) LoadMessages(fsys fs.FS, pattern string) error {
+	_, err := ver.loadMessages(fsys, pattern)
+	return err
+}
+
+// WatchMessages loads bundles like [Verifier.LoadMessages], then polls them
+// every interval and reloads on change until ctx is done. A failed reload
+// keeps the last good bundle and is reported to onError, which may be nil.
+//
+//	err := v.WatchMessages(ctx, os.DirFS("i18n"), "*.yaml", 10*time.Second, func(err error) {
+//	    log.Printf("reload messages: %v", err)
+//	})
+func (ver *Verifier) WatchMessages(ctx context.Context, fsys fs.FS, pattern string, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return errors.New("verify: watch interval must be positive")
+	}
+	sum, err := ver.loadMessages(fsys, pattern)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			files, next, err := readBundles(fsys, pattern)
+			if err == nil && next == sum {
+				continue
+			}
+			if err == nil {
+				err = ver.storeCatalog(files)
+			}
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			sum = next
+		}
+	}()
+	return nil
+}
+
+func (ver *Verifier) loadMessages(fsys fs.FS, pattern string) ([sha256.Size]byte, error) {
+	files, sum, err := readBundles(fsys, pattern)
+	if err != nil {
+		return sum, err
+	}
+	return sum, ver.storeCatalog(files)
+}
+
+func (ver *Verifier) storeCatalog(files []bundleFile) error {
+	cat := &catalog{
+		tags:   make(map[string]message),
+		fields: make(map[string]map[string]message),
+	}
+	for _, f := range files {
+		if err := cat.merge(ver.trans, ver.locale, f); err != nil {
+			return fmt.Errorf("verify: %s: %w", f.name, err)
+		}
+	}
+	ver.catalog.Store(cat)
+	return nil
+}
+
+// ---------- Bundle files ----------
+
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// readBundles reads all files matching pattern in name order and returns
+// them with a digest of their names and contents.
+func readBundles(fsys fs.FS, pattern string) ([]bundleFile, [sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, sum, fmt.Errorf("verify: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, sum, fmt.Errorf("verify: no message bundles match %q", pattern)
+	}
+	slices.Sort(names)
+
+	h := sha256.New()
+	files := make([]bundleFile, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, sum, fmt.Errorf("verify: %w", err)
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(data)
+		h.Write([]byte{0})
+		files = append(files, bundleFile{name: name, data: data})
+	}
+	copy(sum[:], h.Sum(nil))
+	return files, sum, nil
+}
+
+type bundle struct {
+	Locale string                    `json:"locale" yaml:"locale" toml:"locale"`
+	Tags   map[string]any            `json:"tags" yaml:"tags" toml:"tags"`
+	Fields map[string]map[string]any `json:"fields" yaml:"fields" toml:"fields"`
+}
+
+func decodeBundle(f bundleFile) (bundle, error) {
+	var b bundle
+	var err error
+	ext := path.Ext(f.name)
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(f.data, &b)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(f.data, &b)
+	case ".toml":
+		err = toml.Unmarshal(f.data, &b)
+	default:
+		return b, fmt.Errorf("unsupported bundle format %q", ext)
+	}
+	if err != nil {
+		return b, err
+	}
+	if b.Locale == "" {
+		base := strings.TrimSuffix(path.Base(f.name), ext)
+		if i := strings.LastIndexByte(base, '.'); i >= 0 {
+			base = base[i+1:]
+		}
+		b.Locale = base
+	}
+	return b, nil
+}
+
+// ---------- Catalog ----------
+
+// catalog holds the messages of the loaded bundles for one locale.
+// It is immutable once stored.
+type catalog struct {
+	tags   map[string]message            // tag → message
+	fields map[string]map[string]message // namespace → tag → message
+}
+
+func (c *catalog) merge(trans ut.Translator, locale string, f bundleFile) error {
+	b, err := decodeBundle(f)
+	if err != nil {
+		return err
+	}
+	if b.Locale != locale {
+		return nil
+	}
+	for tag, raw := range b.Tags {
+		msg, err := parseMessage(trans, raw)
+		if err != nil {
+			return fmt.Errorf("tag %q: %w", tag, err)
+		}
+		c.tags[tag] = msg
+	}
+	for field, tags := range b.Fields {
+		if c.fields[field] == nil {
+			c.fields[field] = make(map[string]message, len(tags))
+		}
+		for tag, raw := range tags {
+			msg, err := parseMessage(trans, raw)
+			if err != nil {
+				return fmt.Errorf("field %q tag %q: %w", field, tag, err)
+			}
+			c.fields[field][tag] = msg
+		}
+	}
+	return nil
+}
+
+// lookup returns the loaded message for fe, if any. A nil catalog has none.
+func (c *catalog) lookup(trans ut.Translator, fe validator.FieldError) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	// A tag reached through an alias is looked up under the alias first.
+	keys := []string{fe.Tag()}
+	if fe.ActualTag() != fe.Tag() {
+		keys = append(keys, fe.ActualTag())
+	}
+	if tags, ok := c.fields[stripIndexes(fe.Namespace())]; ok {
+		for _, key := range keys {
+			if msg, ok := tags[key]; ok {
+				return msg.format(trans, fe.Field(), fe.Param()), true
+			}
+		}
+	}
+	for _, key := range keys {
+		if msg, ok := c.tags[key]; ok {
+			return msg.format(trans, fe.Field(), fe.Param()), true
+		}
+	}
+	return "", false
+}
+
+// stripIndexes removes slice and map indexes from a namespace.
+// "Order.items[2].name" → "Order.items.name"
+func stripIndexes(ns string) string {
+	if !strings.Contains(ns, "[") {
+		return ns
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range ns {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ---------- Messages ----------
+
+var pluralNames = map[string]locales.PluralRule{
+	"zero":  locales.PluralRuleZero,
+	"one":   locales.PluralRuleOne,
+	"two":   locales.PluralRuleTwo,
+	"few":   locales.PluralRuleFew,
+	"many":  locales.PluralRuleMany,
+	"other": locales.PluralRuleOther,
+}
+
+// message is a translation text, optionally with cardinal plural forms.
+type message struct {
+	text  string
+	forms map[locales.PluralRule]string
+}
+
+func parseMessage(trans ut.Translator, raw any) (message, error) {
+	switch val := raw.(type) {
+	case string:
+		return message{text: val}, nil
+	case map[string]any:
+		msg := message{forms: make(map[locales.PluralRule]string, len(val))}
+		for name, text := range val {
+			rule, ok := pluralNames[name]
+			if !ok {
+				return msg, fmt.Errorf("unknown plural form %q", name)
+			}
+			if !slices.Contains(trans.PluralsCardinal(), rule) {
+				return msg, fmt.Errorf("plural form %q does not exist for locale %q", name, trans.Locale())
+			}
+			s, ok := text.(string)
+			if !ok {
+				return msg, fmt.Errorf("plural form %q must be a string", name)
+			}
+			msg.forms[rule] = s
+		}
+		text, ok := msg.forms[locales.PluralRuleOther]
+		if !ok {
+			return msg, errors.New(`plural forms require "other"`)
+		}
+		msg.text = text
+		return msg, nil
+	default:
+		return message{}, fmt.Errorf("message must be a string or plural forms, got %T", raw)
+	}
+}
+
+func (m message) format(trans ut.Translator, field, param string) string {
+	text := m.text
+	if len(m.forms) > 0 {
+		if rule, num, ok := cardinalRule(trans, param); ok {
+			if form, ok := m.forms[rule]; ok {
+				text = form
+			}
+			param = num
+		}
+	}
+	return strings.NewReplacer("{0}", field, "{1}", param).Replace(text)
+}
+
+// cardinalRule returns the locale's cardinal plural rule for a numeric tag
+// parameter along with the parameter formatted for the locale.
+func cardinalRule(trans ut.Translator, param string) (locales.PluralRule, string, bool) {
+	f, digits, ok := numericParam(param)
+	if !ok {
+		return locales.PluralRuleUnknown, "", false
+	}
+	return trans.CardinalPluralRule(f, digits), trans.FmtNumber(f, digits), true
+}
+
+// ordinalRule is like cardinalRule with the locale's ordinal plural rules.
+func ordinalRule(trans ut.Translator, param string) (locales.PluralRule, string, bool) {
+	f, digits, ok := numericParam(param)
+	if !ok {
+		return locales.PluralRuleUnknown, "", false
+	}
+	return trans.OrdinalPluralRule(f, digits), trans.FmtNumber(f, digits), true
+}
+
+// numericParam parses a numeric tag parameter and counts its decimals.
+func numericParam(param string) (float64, uint64, bool) {
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	var digits uint64
+	if _, frac, ok := strings.Cut(param, "."); ok {
+		digits = uint64(len(frac))
+	}
+	return f, digits, true
+}
@@ -0,0 +1,100 @@
+package verify_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type ItemsParams struct {
+	Name  string   `json:"name" binding:"required"`
+	Items []string `json:"items" binding:"max=1"`
+}
+
+func TestLoadMessages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"i18n/zh.yaml": {Data: []byte(`
+tags:
+  required: "{0}不能为空"
+fields:
+  ItemsParams.name:
+    required: "请填写名称"
+`)},
+		"i18n/messages.en.json": {Data: []byte(`{"tags": {"required": "{0} is missing"}}`)},
+	}
+	v := newVerifier(t)
+	if err := v.LoadMessages(fsys, "i18n/*"); err != nil {
+		t.Fatal(err)
+	}
+
+	all := v.AllFieldErrors(v.Struct(ItemsParams{}))
+	if all["name"] != "请填写名称" {
+		t.Fatalf("expected field override, got %q", all["name"])
+	}
+	if msg := v.FieldErr("type", v.Field("", "required")); !strings.Contains(msg.Error(), "type 不能为空") {
+		t.Fatalf("expected tag override, got %q", msg.Error())
+	}
+}
+
+func TestLoadMessages_Plural(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.toml": {Data: []byte(`
+[tags.max]
+one = "{0} must have at most {1} item"
+other = "{0} must have at most {1} items"
+`)},
+	}
+	v := verify.MustNew(verify.WithLocale("en"))
+	if err := v.LoadMessages(fsys, "*.toml"); err != nil {
+		t.Fatal(err)
+	}
+
+	all := v.AllFieldErrors(v.Struct(ItemsParams{Name: "x", Items: []string{"a", "b"}}))
+	if all["items"] != "items must have at most 1 item" {
+		t.Fatalf("unexpected plural message %q", all["items"])
+	}
+}
+
+func TestLoadMessages_Errors(t *testing.T) {
+	v := newVerifier(t)
+	if err := v.LoadMessages(fstest.MapFS{}, "*.yaml"); err == nil {
+		t.Fatal("expected error for no matches")
+	}
+	bad := fstest.MapFS{"zh.json": {Data: []byte(`{"tags": {"max": {"one": "x"}}}`)}}
+	if err := v.LoadMessages(bad, "*.json"); err == nil {
+		t.Fatal("expected error for plural form missing in locale")
+	}
+}
+
+func TestWatchMessages(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "zh.json")
+	if err := os.WriteFile(file, []byte(`{"tags": {"required": "{0}第一版"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v := newVerifier(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := v.WatchMessages(ctx, os.DirFS(dir), "*.json", 5*time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(`{"tags": {"required": "{0}第二版"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if v.AllFieldErrors(v.Struct(ItemsParams{}))["name"] == "name第二版" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("bundle was not reloaded")
+}
@@ -0,0 +1,163 @@
+package verify
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Observer is notified of the validations run by [Verifier.StructCtx] and
+// [Verifier.MapCtx], and the helpers built on them, for tracing and
+// metrics. The otelx module implements it with OpenTelemetry.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// StartValidation is called before a validation. The returned context,
+	// which may carry a span, is used for the validation and passed to the
+	// other methods.
+	StartValidation(ctx context.Context, v Validation) context.Context
+	// EndPhase is called when a phase of a struct validation ended.
+	EndPhase(ctx context.Context, v Validation, p PhaseTiming)
+	// EndValidation is called with the result of the validation.
+	EndValidation(ctx context.Context, v Validation, r ValidationResult)
+}
+
+// WithObserver sets the [Observer] of the validations. Default: nil.
+func WithObserver(o Observer) Option {
+	return func(c *config) { c.observer = o }
+}
+
+// Operation is the method running an observed validation.
+type Operation string
+
+const (
+	StructOperation Operation = "struct" // [Verifier.StructCtx]
+	MapOperation    Operation = "map"    // [Verifier.MapCtx]
+)
+
+// Validation describes an observed validation.
+type Validation struct {
+	Operation Operation
+	Type      string // validated type, e.g. "handler.Signup"; "map" for MapCtx
+}
+
+// Phases of a struct validation. Phases that have nothing to do for the
+// validated type are not reported.
+const (
+	PhaseTags         = "tags"         // tags, struct-level validations and "when" rules
+	PhaseChecks       = "checks"       // async checks, see [Verifier.RegisterCheck]
+	PhaseValidatables = "validatables" // Validate methods, see [Validatable]
+)
+
+// PhaseTiming is how long a phase of a validation took.
+type PhaseTiming struct {
+	Phase    string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// ValidationResult is the result of an observed validation.
+type ValidationResult struct {
+	Duration time.Duration
+	// Errors holds the violations and warnings; nil if the value is valid.
+	// The fields of MapCtx violations are the keys of the map, e.g.
+	// "user.name" for a nested rule map.
+	Errors *Errors
+	// Err is set when the value could not be validated, such as an invalid
+	// argument or a canceled context.
+	Err error
+}
+
+// observation tracks a validation for the observer; a nil observation
+// observes nothing.
+type observation struct {
+	ver   *Verifier
+	v     Validation
+	start time.Time
+	mark  time.Time // end of the last phase
+}
+
+// observe starts observing a validation of s, if there is an observer.
+func (ver *Verifier) observe(ctx context.Context, op Operation, s any) (context.Context, *observation) {
+	if ver.observer == nil {
+		return ctx, nil
+	}
+	ob := &observation{ver: ver, v: Validation{Operation: op, Type: validatedType(s)}}
+	if op == MapOperation {
+		ob.v.Type = "map"
+	}
+	ctx = ver.observer.StartValidation(ctx, ob.v)
+	ob.start = time.Now()
+	ob.mark = ob.start
+	return ctx, ob
+}
+
+// phase ends the current phase, reporting it if ran is set.
+func (ob *observation) phase(ctx context.Context, name string, ran bool) {
+	if ob == nil {
+		return
+	}
+	now := time.Now()
+	if ran {
+		ob.ver.observer.EndPhase(ctx, ob.v, PhaseTiming{Phase: name, Start: ob.mark, Duration: now.Sub(ob.mark)})
+	}
+	ob.mark = now
+}
+
+// end reports the result err of a struct validation, translated into errs.
+func (ob *observation) end(ctx context.Context, err error, errs *Errors) {
+	if ob == nil {
+		return
+	}
+	r := ValidationResult{Duration: time.Since(ob.start)}
+	if r.Errors = errs; r.Errors == nil {
+		r.Err = err
+	}
+	ob.ver.observer.EndValidation(ctx, ob.v, r)
+}
+
+// endMap reports the result of a map validation.
+func (ob *observation) endMap(ctx context.Context, result map[string]any) {
+	if ob == nil {
+		return
+	}
+	r := ValidationResult{Duration: time.Since(ob.start)}
+	errs := &Errors{}
+	ob.ver.mapViolations(errs, "", result)
+	if len(errs.Violations) > 0 {
+		r.Errors = errs
+	}
+	ob.ver.observer.EndValidation(ctx, ob.v, r)
+}
+
+// mapViolations adds the violations of a map validation result to errs,
+// under the key path prefix.
+func (ver *Verifier) mapViolations(errs *Errors, prefix string, result map[string]any) {
+	for _, key := range slices.Sorted(maps.Keys(result)) {
+		switch val := result[key].(type) {
+		case validator.ValidationErrors:
+			for _, fe := range val {
+				fv := ver.violation(fe)
+				fv.Field, fv.StructField = prefix+key, prefix+key
+				errs.Violations = append(errs.Violations, fv)
+			}
+		case map[string]any:
+			ver.mapViolations(errs, prefix+key+".", val)
+		}
+	}
+}
+
+// validatedType returns the name of the type of s, without pointers.
+func validatedType(s any) string {
+	t := reflect.TypeOf(s)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
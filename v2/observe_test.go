@@ -0,0 +1,97 @@
+package verify_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type ctxKey struct{}
+
+// recorder is an [verify.Observer] recording what it is told.
+type recorder struct {
+	mu      sync.Mutex
+	events  []string
+	results []verify.ValidationResult
+}
+
+func (r *recorder) StartValidation(ctx context.Context, v verify.Validation) context.Context {
+	r.record("start %s %s", v.Operation, v.Type)
+	return context.WithValue(ctx, ctxKey{}, "span")
+}
+
+func (r *recorder) EndPhase(ctx context.Context, v verify.Validation, p verify.PhaseTiming) {
+	r.record("phase %s %s", p.Phase, ctx.Value(ctxKey{}))
+}
+
+func (r *recorder) EndValidation(ctx context.Context, v verify.Validation, res verify.ValidationResult) {
+	r.record("end %s %s", v.Type, ctx.Value(ctxKey{}))
+	r.mu.Lock()
+	r.results = append(r.results, res)
+	r.mu.Unlock()
+}
+
+func (r *recorder) record(format string, args ...any) {
+	r.mu.Lock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+	r.mu.Unlock()
+}
+
+func TestWithObserver(t *testing.T) {
+	rec := &recorder{}
+	v := verify.MustNew(verify.WithObserver(rec))
+	if err := v.RegisterCheck("unique_username", uniqueUsername); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Struct(&RegisterParams{Username: "alice"}); err == nil {
+		t.Fatal("expected the check to fail")
+	}
+	want := "[start struct verify_test.RegisterParams phase tags span phase checks span end verify_test.RegisterParams span]"
+	if got := fmt.Sprint(rec.events); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	res := rec.results[0]
+	if res.Errors.Len() != 1 || res.Errors.Violations[0].Tag != "unique_username" || res.Err != nil || res.Duration <= 0 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	rec.events, rec.results = nil, nil
+	if err := v.Struct(Period{Start: 2, End: 1}); err == nil {
+		t.Fatal("expected Validate to fail")
+	}
+	if got := fmt.Sprint(rec.events); got != "[start struct verify_test.Period phase tags span phase validatables span end verify_test.Period span]" {
+		t.Fatalf("unexpected events %s", got)
+	}
+
+	rec.events, rec.results = nil, nil
+	if err := v.Struct(42); err == nil {
+		t.Fatal("expected an invalid argument error")
+	}
+	if res := rec.results[0]; res.Errors != nil || res.Err == nil {
+		t.Fatalf("expected Err to be set, got %+v", res)
+	}
+}
+
+func TestWithObserver_Map(t *testing.T) {
+	rec := &recorder{}
+	v := verify.MustNew(verify.WithObserver(rec))
+
+	v.Map(map[string]any{"name": "", "user": map[string]any{"age": 200}}, map[string]any{
+		"name": "required",
+		"user": map[string]any{"age": "max=150"},
+	})
+	if got := fmt.Sprint(rec.events); got != "[start map map end map span]" {
+		t.Fatalf("unexpected events %s", got)
+	}
+	var fields []string
+	for _, fv := range rec.results[0].Errors.Violations {
+		fields = append(fields, fv.Field+" "+fv.Tag)
+	}
+	if got := fmt.Sprint(fields); got != "[name required user.age max]" {
+		t.Fatalf("unexpected violations %s", got)
+	}
+}
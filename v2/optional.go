@@ -0,0 +1,133 @@
+package verify
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Optional is a value that may be absent, null or set, as decoded from a
+// JSON field. The Verifier applies a field's tags to the value it holds,
+// or to nil if it has none, so "omitempty" skips absent and null values
+// and "required" rejects them.
+//
+//	type PatchUser struct {
+//	    Name verify.Optional[string] `json:"name,omitzero" binding:"omitempty,min=2"`
+//	    Age  verify.Optional[int]    `json:"age,omitzero" binding:"omitempty,gte=1"`
+//	}
+//
+// Optional of the basic types and time.Time is registered with every
+// Verifier; register other instantiations with [WithCustomTypeFunc] and
+// [UnwrapOptional].
+type Optional[T any] struct {
+	value   T
+	present bool
+	valid   bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, present: true, valid: true}
+}
+
+// Null returns an Optional that is present but null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{present: true}
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) { return o.value, o.valid }
+
+// Present reports whether the value was given, even as null.
+func (o Optional[T]) Present() bool { return o.present }
+
+// IsNull reports whether the value was given as null.
+func (o Optional[T]) IsNull() bool { return o.present && !o.valid }
+
+// IsZero reports whether the value is absent, so the "omitzero" JSON
+// option omits it.
+func (o Optional[T]) IsZero() bool { return !o.present }
+
+// MarshalJSON encodes the value, or null if it is not set.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value or null. A field missing from the JSON
+// leaves the Optional absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.value, o.present, o.valid = zero, true, false
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.value); err != nil {
+		return err
+	}
+	o.valid = true
+	return nil
+}
+
+func (o Optional[T]) optionalValue() (any, bool) { return o.value, o.valid }
+
+// UnwrapOptional is a [validator.CustomTypeFunc] returning the value held by
+// an [Optional], or nil.
+func UnwrapOptional(field reflect.Value) any {
+	if !field.CanInterface() {
+		return nil
+	}
+	if o, ok := field.Interface().(interface{ optionalValue() (any, bool) }); ok {
+		if v, ok := o.optionalValue(); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// UnwrapValuer is a [validator.CustomTypeFunc] returning the value of a
+// [driver.Valuer], such as sql.NullString or uuid.UUID, or nil if it is
+// null or fails. Use [UnwrapDecimal] for decimal types.
+func UnwrapValuer(field reflect.Value) any {
+	if !field.CanInterface() {
+		return nil
+	}
+	if valuer, ok := field.Interface().(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// UnwrapDecimal is like [UnwrapValuer] for decimal types such as
+// decimal.Decimal, whose Value is a string: it returns that string as a
+// float64, so numeric tags like "gt" compare the number rather than the
+// length of its text. Values that do not parse are returned as they are.
+func UnwrapDecimal(field reflect.Value) any {
+	v := UnwrapValuer(field)
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+var sqlNullTypes = []any{
+	sql.NullString{}, sql.NullInt64{}, sql.NullInt32{}, sql.NullInt16{},
+	sql.NullByte{}, sql.NullFloat64{}, sql.NullBool{}, sql.NullTime{},
+}
+
+var optionalTypes = []any{
+	Optional[string]{}, Optional[bool]{},
+	Optional[int]{}, Optional[int8]{}, Optional[int16]{}, Optional[int32]{}, Optional[int64]{},
+	Optional[uint]{}, Optional[uint8]{}, Optional[uint16]{}, Optional[uint32]{}, Optional[uint64]{},
+	Optional[float32]{}, Optional[float64]{}, Optional[time.Time]{},
+}
@@ -0,0 +1,116 @@
+package verify_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type PatchUser struct {
+	Name  verify.Optional[string] `json:"name,omitzero" binding:"omitempty,min=2"`
+	Age   verify.Optional[int]    `json:"age,omitzero" binding:"required,gte=1"`
+	Email sql.NullString          `json:"-" binding:"omitempty,email"`
+}
+
+func TestOptional_JSON(t *testing.T) {
+	var p struct {
+		A verify.Optional[int] `json:"a,omitzero"`
+		B verify.Optional[int] `json:"b,omitzero"`
+		C verify.Optional[int] `json:"c,omitzero"`
+	}
+	if err := json.Unmarshal([]byte(`{"b":null,"c":3}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.A.Present() || !p.B.IsNull() {
+		t.Fatalf("absent/null not distinguished: %+v", p)
+	}
+	if c, ok := p.C.Get(); !ok || c != 3 {
+		t.Fatalf("expected c=3, got %v %v", c, ok)
+	}
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"b":null,"c":3}` {
+		t.Fatalf("unexpected JSON %s", out)
+	}
+}
+
+func TestOptional_Validation(t *testing.T) {
+	v := newVerifier(t)
+	cases := []struct {
+		body  string
+		email sql.NullString
+		want  map[string]string
+	}{
+		{`{"age":20}`, sql.NullString{}, nil},
+		{`{"name":null,"age":20}`, sql.NullString{String: "a@b.com", Valid: true}, nil},
+		{`{"name":"a","age":20}`, sql.NullString{}, map[string]string{"name": "name长度必须至少为2个字符"}},
+		{`{"name":"alice"}`, sql.NullString{}, map[string]string{"age": "age为必填字段"}},
+		{`{"age":-1}`, sql.NullString{String: "bad", Valid: true}, map[string]string{
+			"age":   "age必须大于或等于1",
+			"Email": "Email必须是一个有效的邮箱",
+		}},
+	}
+	for _, tc := range cases {
+		p := PatchUser{Email: tc.email}
+		if err := json.Unmarshal([]byte(tc.body), &p); err != nil {
+			t.Fatal(err)
+		}
+		got := v.AllFieldErrors(v.Struct(p))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.body, tc.want, got)
+		}
+		for k, msg := range tc.want {
+			if got[k] != msg {
+				t.Fatalf("%s: expected %s=%q, got %v", tc.body, k, msg, got)
+			}
+		}
+	}
+}
+
+type Color string
+
+type Palette struct {
+	Primary verify.Optional[Color] `json:"primary" binding:"required,oneof=red green"`
+}
+
+func TestWithCustomTypeFunc(t *testing.T) {
+	v := verify.MustNew(verify.WithCustomTypeFunc(verify.UnwrapOptional, verify.Optional[Color]{}))
+	if err := v.Struct(Palette{Primary: verify.Some[Color]("red")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Struct(Palette{Primary: verify.Some[Color]("blue")}); err == nil {
+		t.Fatal("expected oneof error")
+	}
+	if _, err := verify.New(verify.WithCustomTypeFunc(nil, Color(""))); err == nil {
+		t.Fatal("expected error for nil func")
+	}
+	if got := verify.UnwrapValuer(reflect.ValueOf(sql.NullInt64{Int64: 7, Valid: true})); got != int64(7) {
+		t.Fatalf("expected 7, got %v", got)
+	}
+}
+
+// Money stands in for decimal.Decimal, a driver.Valuer of a decimal string.
+type Money struct{ text string }
+
+func (m Money) Value() (driver.Value, error) { return m.text, nil }
+
+func TestUnwrapDecimal(t *testing.T) {
+	type Order struct {
+		Total Money `json:"total" binding:"gt=10,lte=1000"`
+	}
+	v := verify.MustNew(verify.WithCustomTypeFunc(verify.UnwrapDecimal, Money{}))
+	if err := v.Struct(Order{Total: Money{"12.50"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, total := range []string{"9.99", "1000.01", "abc"} {
+		if err := v.Struct(Order{Total: Money{total}}); err == nil {
+			t.Fatalf("%s: expected an error", total)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Package otelx traces and measures the validations of a
+// [verify.Verifier] with OpenTelemetry.
+//
+//	obs, err := otelx.New()
+//	if err != nil {
+//	    return err
+//	}
+//	v := verify.MustNew(verify.WithObserver(obs))
+//
+// Each [verify.Verifier.StructCtx] and [verify.Verifier.MapCtx] call gets a
+// "verify.Struct" or "verify.Map" span with the validated type and the
+// outcome, and a child span for each of its phases. The metrics are:
+//
+//   - verify.validations: validations by operation, type and outcome
+//   - verify.violations: violations by type, field and tag
+//   - verify.duration: validation duration in seconds, by operation, type and outcome
+//   - verify.phase.duration: phase duration in seconds, by type and phase
+//
+// Field names are reported without slice indexes and map keys, e.g.
+// "items[].sku", to keep the number of series bounded.
+package otelx
+
+import (
+	"context"
+	"regexp"
+
+	verify "github.com/gtkit/verify/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/gtkit/verify/v2/otelx"
+
+// Outcomes of a validation, the value of the verify.outcome attribute.
+const (
+	OutcomeValid   = "valid"
+	OutcomeInvalid = "invalid"
+	OutcomeError   = "error" // the value could not be validated
+)
+
+// Attribute keys.
+const (
+	OperationKey  = attribute.Key("verify.operation")
+	TypeKey       = attribute.Key("verify.type")
+	OutcomeKey    = attribute.Key("verify.outcome")
+	PhaseKey      = attribute.Key("verify.phase")
+	FieldKey      = attribute.Key("verify.field")
+	TagKey        = attribute.Key("verify.tag")
+	ViolationsKey = attribute.Key("verify.violations")
+	WarningsKey   = attribute.Key("verify.warnings")
+)
+
+// Option configures an [Observer].
+type Option func(*options)
+
+type options struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider. Default: otel.GetTracerProvider().
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) { o.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the meter provider. Default: otel.GetMeterProvider().
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) { o.meterProvider = mp }
+}
+
+// Observer is a [verify.Observer] recording spans and metrics.
+type Observer struct {
+	tracer        trace.Tracer
+	validations   metric.Int64Counter
+	violations    metric.Int64Counter
+	duration      metric.Float64Histogram
+	phaseDuration metric.Float64Histogram
+}
+
+var _ verify.Observer = (*Observer)(nil)
+
+// New returns an Observer using the global providers unless set otherwise.
+func New(opts ...Option) (*Observer, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.tracerProvider == nil {
+		o.tracerProvider = otel.GetTracerProvider()
+	}
+	if o.meterProvider == nil {
+		o.meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := o.meterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(verify.Version))
+	obs := &Observer{tracer: o.tracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(verify.Version))}
+	var err error
+	if obs.validations, err = meter.Int64Counter("verify.validations",
+		metric.WithDescription("Number of validations."), metric.WithUnit("{validation}")); err != nil {
+		return nil, err
+	}
+	if obs.violations, err = meter.Int64Counter("verify.violations",
+		metric.WithDescription("Number of violations."), metric.WithUnit("{violation}")); err != nil {
+		return nil, err
+	}
+	if obs.duration, err = meter.Float64Histogram("verify.duration",
+		metric.WithDescription("Duration of validations."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if obs.phaseDuration, err = meter.Float64Histogram("verify.phase.duration",
+		metric.WithDescription("Duration of the phases of struct validations."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return obs, nil
+}
+
+// StartValidation starts the span of a validation.
+func (obs *Observer) StartValidation(ctx context.Context, v verify.Validation) context.Context {
+	name := "verify.Struct"
+	if v.Operation == verify.MapOperation {
+		name = "verify.Map"
+	}
+	ctx, _ = obs.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(OperationKey.String(string(v.Operation)), TypeKey.String(v.Type)))
+	return ctx
+}
+
+// EndPhase records a phase as a child span of the validation.
+func (obs *Observer) EndPhase(ctx context.Context, v verify.Validation, p verify.PhaseTiming) {
+	_, span := obs.tracer.Start(ctx, "verify."+p.Phase, trace.WithTimestamp(p.Start),
+		trace.WithAttributes(TypeKey.String(v.Type), PhaseKey.String(p.Phase)))
+	span.End(trace.WithTimestamp(p.Start.Add(p.Duration)))
+	obs.phaseDuration.Record(ctx, p.Duration.Seconds(),
+		metric.WithAttributes(TypeKey.String(v.Type), PhaseKey.String(p.Phase)))
+}
+
+// EndValidation records the outcome and ends the span of a validation.
+func (obs *Observer) EndValidation(ctx context.Context, v verify.Validation, r verify.ValidationResult) {
+	span := trace.SpanFromContext(ctx)
+	outcome := OutcomeValid
+	switch {
+	case r.Err != nil:
+		outcome = OutcomeError
+		span.RecordError(r.Err)
+		span.SetStatus(codes.Error, r.Err.Error())
+	case r.Errors.Len() > 0:
+		outcome = OutcomeInvalid
+	}
+	span.SetAttributes(OutcomeKey.String(outcome), ViolationsKey.Int(r.Errors.Len()))
+	if r.Errors != nil && len(r.Errors.Warnings) > 0 {
+		span.SetAttributes(WarningsKey.Int(len(r.Errors.Warnings)))
+	}
+	span.End()
+
+	attrs := metric.WithAttributes(OperationKey.String(string(v.Operation)), TypeKey.String(v.Type), OutcomeKey.String(outcome))
+	obs.validations.Add(ctx, 1, attrs)
+	obs.duration.Record(ctx, r.Duration.Seconds(), attrs)
+	if r.Errors == nil {
+		return
+	}
+	for _, fv := range r.Errors.Violations {
+		obs.violations.Add(ctx, 1, metric.WithAttributes(
+			TypeKey.String(v.Type), FieldKey.String(fieldName(fv.Field)), TagKey.String(fv.Tag)))
+	}
+}
+
+var indexes = regexp.MustCompile(`\[[^\]]*\]`)
+
+// fieldName drops the slice indexes and map keys of a field namespace.
+func fieldName(ns string) string {
+	return indexes.ReplaceAllString(ns, "[]")
+}
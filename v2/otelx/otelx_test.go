@@ -0,0 +1,118 @@
+package otelx_test
+
+import (
+	"context"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/otelx"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type OrderItem struct {
+	SKU string `json:"sku" binding:"required"`
+}
+
+type Order struct {
+	ID    string      `json:"id" binding:"required"`
+	Items []OrderItem `json:"items" binding:"dive"`
+}
+
+func (o Order) Validate(context.Context) error { return nil }
+
+func setup(t *testing.T) (*verify.Verifier, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	obs, err := otelx.New(
+		otelx.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))),
+		otelx.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verify.MustNew(verify.WithObserver(obs)), spans, reader
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) string {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestObserver_Spans(t *testing.T) {
+	v, spans, _ := setup(t)
+	_ = v.Struct(&Order{Items: []OrderItem{{}, {SKU: "a"}}})
+
+	got := spans.GetSpans()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(got))
+	}
+	root := got[len(got)-1]
+	if root.Name != "verify.Struct" || attr(root.Attributes, otelx.TypeKey) != "otelx_test.Order" ||
+		attr(root.Attributes, otelx.OutcomeKey) != otelx.OutcomeInvalid || attr(root.Attributes, otelx.ViolationsKey) != "2" {
+		t.Fatalf("unexpected span %s %v", root.Name, root.Attributes)
+	}
+	for _, phase := range got[:2] {
+		if phase.Parent.SpanID() != root.SpanContext.SpanID() {
+			t.Fatalf("expected %s to be a child of the validation span", phase.Name)
+		}
+	}
+	if got[0].Name != "verify.tags" || got[1].Name != "verify.validatables" {
+		t.Fatalf("unexpected phase spans %s, %s", got[0].Name, got[1].Name)
+	}
+
+	spans.Reset()
+	_ = v.Struct(42)
+	if s := spans.GetSpans()[len(spans.GetSpans())-1]; attr(s.Attributes, otelx.OutcomeKey) != otelx.OutcomeError || s.Status.Description == "" {
+		t.Fatalf("expected an error span, got %v %v", s.Attributes, s.Status)
+	}
+}
+
+func TestObserver_Metrics(t *testing.T) {
+	v, _, reader := setup(t)
+	_ = v.Struct(&Order{Items: []OrderItem{{}, {}}})
+	_ = v.Struct(&Order{ID: "o-1"})
+	v.Map(map[string]any{"name": ""}, map[string]any{"name": "required"})
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		data, ok := m.Data.(metricdata.Sum[int64])
+		if !ok {
+			continue
+		}
+		for _, dp := range data.DataPoints {
+			key := m.Name
+			for _, k := range []attribute.Key{otelx.OperationKey, otelx.TypeKey, otelx.OutcomeKey, otelx.FieldKey, otelx.TagKey} {
+				if val, ok := dp.Attributes.Value(k); ok {
+					key += " " + val.Emit()
+				}
+			}
+			sums[key] += dp.Value
+		}
+	}
+	want := map[string]int64{
+		"verify.validations struct otelx_test.Order invalid":      1,
+		"verify.validations struct otelx_test.Order valid":        1,
+		"verify.validations map map invalid":                      1,
+		"verify.violations otelx_test.Order id required":          1,
+		"verify.violations otelx_test.Order items[].sku required": 2,
+		"verify.violations map name required":                     1,
+	}
+	for key, n := range want {
+		if sums[key] != n {
+			t.Fatalf("%s: expected %d, got %d (all: %v)", key, n, sums[key], sums)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package verify
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// passwordTag is the alias registered by [Verifier.RegisterPasswordPolicy].
+const passwordTag = "password"
+
+// PasswordPolicy configures the "password" tag. Each rule is a tag of its
+// own, so a failing password gets the message of the first rule it breaks:
+//
+//	pwd_min=8    at least 8 characters
+//	pwd_upper    an uppercase letter
+//	pwd_lower    a lowercase letter
+//	pwd_digit    a digit
+//	pwd_symbol   a character that is not a letter or digit
+//	pwd_user=a b does not contain the values of sibling fields a and b
+//	pwd_common   not in the embedded list of common passwords
+//	pwd_score=2  a [PasswordScore] of at least 2
+//
+// The rule tags can also be used directly.
+type PasswordPolicy struct {
+	MinLength  int
+	Upper      bool
+	Lower      bool
+	Digit      bool
+	Symbol     bool
+	UserFields []string // tag or Go names of fields such as username and email; absent ones are skipped
+	Common     bool
+	MinScore   int // 0–4, 0 disables
+}
+
+// DefaultPasswordPolicy requires 8 characters with upper and lower case
+// letters and a digit, rejects common passwords and a score below 2.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 8, Upper: true, Lower: true, Digit: true, Common: true, MinScore: 2}
+}
+
+// Tags returns the tags the "password" alias expands to.
+func (p PasswordPolicy) Tags() string {
+	var tags []string
+	if p.MinLength > 0 {
+		tags = append(tags, "pwd_min="+strconv.Itoa(p.MinLength))
+	}
+	for _, rule := range []struct {
+		on  bool
+		tag string
+	}{{p.Upper, "pwd_upper"}, {p.Lower, "pwd_lower"}, {p.Digit, "pwd_digit"}, {p.Symbol, "pwd_symbol"}} {
+		if rule.on {
+			tags = append(tags, rule.tag)
+		}
+	}
+	if len(p.UserFields) > 0 {
+		tags = append(tags, "pwd_user="+strings.Join(p.UserFields, " "))
+	}
+	if p.Common {
+		tags = append(tags, "pwd_common")
+	}
+	if p.MinScore > 0 {
+		tags = append(tags, "pwd_score="+strconv.Itoa(p.MinScore))
+	}
+	return strings.Join(tags, ",")
+}
+
+func (p PasswordPolicy) validate() error {
+	if p.MinLength < 0 || p.MinScore < 0 || p.MinScore > 4 {
+		return fmt.Errorf("verify: invalid password policy: min length %d, min score %d", p.MinLength, p.MinScore)
+	}
+	for _, name := range p.UserFields {
+		if name == "" || strings.ContainsAny(name, " ,|=") {
+			return fmt.Errorf("verify: invalid password policy user field %q", name)
+		}
+	}
+	if p.Tags() == "" {
+		return errors.New("verify: password policy has no rules")
+	}
+	return nil
+}
+
+// WithPasswordPolicy registers the "password" tag, see
+// [Verifier.RegisterPasswordPolicy].
+func WithPasswordPolicy(p PasswordPolicy) Option {
+	return func(c *config) { c.passwordPolicy = &p }
+}
+
+// RegisterPasswordPolicy registers the pwd_* rule tags with their
+// translations and the "password" tag for policy p. Calling it again
+// replaces the policy.
+//
+//	v.RegisterPasswordPolicy(verify.PasswordPolicy{
+//	    MinLength: 10, Upper: true, Digit: true,
+//	    UserFields: []string{"username", "email"},
+//	    Common: true, MinScore: 3,
+//	})
+//
+//	type SignUp struct {
+//	    Username string `json:"username" binding:"required"`
+//	    Email    string `json:"email" binding:"required,email"`
+//	    Password string `json:"password" binding:"required,password"` // → "password需包含大写字母"
+//	}
+func (ver *Verifier) RegisterPasswordPolicy(p PasswordPolicy) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	for tag, fn := range map[string]validator.Func{
+		"pwd_min":    passwordMin,
+		"pwd_upper":  passwordHas(unicode.IsUpper),
+		"pwd_lower":  passwordHas(unicode.IsLower),
+		"pwd_digit":  passwordHas(unicode.IsDigit),
+		"pwd_symbol": passwordHas(isSymbol),
+		"pwd_user":   ver.passwordUser,
+		"pwd_common": passwordCommon,
+		"pwd_score":  passwordMinScore,
+	} {
+		if err := ver.validate.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+		msg := passwordText(ver.locale, tag)
+		if err := ver.validate.RegisterTranslation(tag, ver.trans, RegisterTranslator(tag, msg), translateActual); err != nil {
+			return err
+		}
+	}
+	ver.validate.RegisterAlias(passwordTag, p.Tags())
+	return nil
+}
+
+var passwordTexts = map[string]map[string]string{
+	"zh": {
+		"pwd_min":    "{0}长度不能少于{1}个字符",
+		"pwd_upper":  "{0}需包含大写字母",
+		"pwd_lower":  "{0}需包含小写字母",
+		"pwd_digit":  "{0}需包含数字",
+		"pwd_symbol": "{0}需包含特殊字符",
+		"pwd_user":   "{0}不能包含用户名或邮箱",
+		"pwd_common": "{0}过于常见",
+		"pwd_score":  "{0}强度不足",
+	},
+	"en": {
+		"pwd_min":    "{0} must be at least {1} characters long",
+		"pwd_upper":  "{0} must contain an uppercase letter",
+		"pwd_lower":  "{0} must contain a lowercase letter",
+		"pwd_digit":  "{0} must contain a digit",
+		"pwd_symbol": "{0} must contain a special character",
+		"pwd_user":   "{0} must not contain the username or email",
+		"pwd_common": "{0} is too common",
+		"pwd_score":  "{0} is too weak",
+	},
+}
+
+func passwordText(locale, tag string) string {
+	if texts, ok := passwordTexts[locale]; ok {
+		return texts[tag]
+	}
+	return passwordTexts["en"][tag]
+}
+
+// translateActual is a [validator.TranslationFunc] keyed on the actual tag,
+// so that tags reached through an alias keep their own message.
+func translateActual(trans ut.Translator, fe validator.FieldError) string {
+	msg, err := trans.T(fe.ActualTag(), fe.Field(), fe.Param())
+	if err != nil {
+		return Translate(trans, fe)
+	}
+	return msg
+}
+
+// ---------- Rules ----------
+
+func passwordMin(fl validator.FieldLevel) bool {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("verify: bad pwd_min parameter %q", fl.Param()))
+	}
+	return utf8.RuneCountInString(fl.Field().String()) >= n
+}
+
+func passwordHas(class func(rune) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return strings.ContainsFunc(fl.Field().String(), class)
+	}
+}
+
+func isSymbol(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+
+// passwordUser reports whether the password contains none of the sibling
+// fields listed in the parameter, ignoring case. For an email address the
+// local part is checked too; values shorter than 3 characters and fields
+// the struct does not have are ignored.
+func (ver *Verifier) passwordUser(fl validator.FieldLevel) bool {
+	pw := strings.ToLower(fl.Field().String())
+	for _, name := range strings.Fields(fl.Param()) {
+		v, err := ver.member(fl.Parent(), name)
+		if err != nil {
+			continue
+		}
+		v = indirect(v)
+		if !v.IsValid() || v.Kind() != reflect.String {
+			continue
+		}
+		value := strings.ToLower(v.String())
+		candidates := []string{value}
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			candidates = append(candidates, local)
+		}
+		for _, c := range candidates {
+			if utf8.RuneCountInString(c) >= 3 && strings.Contains(pw, c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func passwordCommon(fl validator.FieldLevel) bool {
+	return !isCommonPassword(fl.Field().String())
+}
+
+func passwordMinScore(fl validator.FieldLevel) bool {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("verify: bad pwd_score parameter %q", fl.Param()))
+	}
+	return PasswordScore(fl.Field().String()) >= n
+}
+
+// ---------- Strength ----------
+
+//go:embed passwords.txt
+var passwordsTxt string
+
+var commonPasswords = sync.OnceValue(func() map[string]bool {
+	m := make(map[string]bool)
+	for line := range strings.Lines(passwordsTxt) {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			m[line] = true
+		}
+	}
+	return m
+})
+
+func isCommonPassword(pw string) bool {
+	return commonPasswords()[strings.ToLower(pw)]
+}
+
+// keyboardRows are scanned for runs such as "qwer" and "asdf".
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// PasswordScore estimates the strength of a password from 0 (too
+// guessable) to 4 (very unguessable), in the spirit of zxcvbn: the entropy
+// of the characters used, discounted for common passwords and words,
+// repeats, sequences such as "abc" or "321" and keyboard runs.
+func PasswordScore(password string) int {
+	bits := passwordEntropy(password)
+	// log2 of zxcvbn's guess thresholds 10³, 10⁶, 10⁸ and 10¹⁰.
+	for score, limit := range []float64{10, 20, 26.6, 33.2} {
+		if bits < limit {
+			return score
+		}
+	}
+	return 4
+}
+
+// passwordEntropy estimates the entropy of pw in bits.
+func passwordEntropy(pw string) float64 {
+	if pw == "" || isCommonPassword(pw) {
+		return 0
+	}
+	runes := []rune(pw)
+	lower := []rune(strings.ToLower(pw))
+
+	var pool float64
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+	for _, r := range runes {
+		switch {
+		case r < utf8.RuneSelf && unicode.IsLower(r):
+			hasLower = true
+		case r < utf8.RuneSelf && unicode.IsUpper(r):
+			hasUpper = true
+		case r < utf8.RuneSelf && unicode.IsDigit(r):
+			hasDigit = true
+		case r < utf8.RuneSelf:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+	for _, c := range []struct {
+		on   bool
+		size float64
+	}{{hasLower, 26}, {hasUpper, 26}, {hasDigit, 10}, {hasSymbol, 33}, {hasOther, 100}} {
+		if c.on {
+			pool += c.size
+		}
+	}
+	perChar := math.Log2(pool)
+	dictBits := math.Log2(float64(len(commonPasswords())))
+
+	unleeted := []rune(strings.Map(unleet, string(lower)))
+
+	var bits float64
+	for i := 0; i < len(runes); {
+		// A common password embedded in a longer one, possibly with
+		// substitutions such as "p@ssw0rd", costs one dictionary guess.
+		if n := max(commonPrefix(lower[i:]), commonPrefix(unleeted[i:])); n > 0 {
+			bits += dictBits
+			i += n
+			continue
+		}
+		if i > 0 && predictable(lower[i-1], lower[i]) {
+			bits++
+		} else {
+			bits += perChar
+		}
+		i++
+	}
+	return bits
+}
+
+// commonPrefix returns the length of the longest common password of at
+// least 4 characters that s starts with, or 0.
+func commonPrefix(s []rune) int {
+	for n := min(len(s), 16); n >= 4; n-- {
+		if commonPasswords()[string(s[:n])] {
+			return n
+		}
+	}
+	return 0
+}
+
+// unleet undoes common character substitutions.
+func unleet(r rune) rune {
+	switch r {
+	case '@', '4':
+		return 'a'
+	case '3':
+		return 'e'
+	case '1', '!':
+		return 'i'
+	case '0':
+		return 'o'
+	case '$', '5':
+		return 's'
+	case '7':
+		return 't'
+	}
+	return r
+}
+
+// predictable reports whether r follows prev as a repeat, a step of one
+// such as "ab" or "21", or a neighbouring key.
+func predictable(prev, r rune) bool {
+	if d := r - prev; d >= -1 && d <= 1 {
+		return true
+	}
+	for _, row := range keyboardRows {
+		if i := strings.IndexRune(row, prev); i >= 0 {
+			j := strings.IndexRune(row, r)
+			return j >= 0 && (j == i+1 || j == i-1)
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package verify_test
+
+import (
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type SignUpForm struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password" binding:"required,password"`
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := verify.DefaultPasswordPolicy()
+	policy.Symbol = true
+	policy.UserFields = []string{"username", "Email"}
+	v := verify.MustNew(verify.WithLocale("zh"), verify.WithPasswordPolicy(policy))
+
+	cases := []struct {
+		a    SignUpForm
+		want string
+	}{
+		{SignUpForm{Username: "gopher", Email: "go@example.com", Password: "Kx9#mPq2vL"}, ""},
+		{SignUpForm{Password: ""}, "password为必填字段"},
+		{SignUpForm{Password: "Kx9#m"}, "password长度不能少于8个字符"},
+		{SignUpForm{Password: "kx9#mpq2vl"}, "password需包含大写字母"},
+		{SignUpForm{Password: "KX9#MPQ2VL"}, "password需包含小写字母"},
+		{SignUpForm{Password: "Kx#mPqzvL!"}, "password需包含数字"},
+		{SignUpForm{Password: "Kx9mPq2vLw"}, "password需包含特殊字符"},
+		{SignUpForm{Username: "gopher", Password: "Gopher#2024x"}, "password不能包含用户名或邮箱"},
+		{SignUpForm{Email: "rob.pike@example.com", Password: "xRob.Pike#1"}, "password不能包含用户名或邮箱"},
+		{SignUpForm{Username: "go", Password: "Go#9mPq2vLx"}, ""},
+		{SignUpForm{Password: "P@ssw0rd"}, "password强度不足"},
+	}
+	for _, tc := range cases {
+		got := v.AllFieldErrors(v.Struct(tc.a))["password"]
+		if got != tc.want {
+			t.Fatalf("%+v: expected %q, got %q", tc.a, tc.want, got)
+		}
+	}
+}
+
+func TestPasswordPolicy_NoUserFields(t *testing.T) {
+	policy := verify.DefaultPasswordPolicy()
+	policy.UserFields = []string{"username", "email"}
+	v := verify.MustNew(verify.WithLocale("zh"), verify.WithPasswordPolicy(policy))
+
+	type ResetForm struct {
+		Password string `json:"password" binding:"required,password"`
+	}
+	if err := v.Struct(ResetForm{Password: "Kx9#mPq2vL"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPasswordPolicy_Common(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"), verify.WithPasswordPolicy(verify.PasswordPolicy{Common: true}))
+	if got := v.AllFieldErrors(v.Struct(SignUpForm{Password: "Password1"}))["password"]; got != "password is too common" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	if err := v.Struct(SignUpForm{Password: "Password1!"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestPasswordPolicy_Invalid(t *testing.T) {
+	v := newVerifier(t)
+	for _, p := range []verify.PasswordPolicy{
+		{},
+		{MinLength: -1},
+		{MinScore: 5},
+		{Upper: true, UserFields: []string{"user name"}},
+	} {
+		if err := v.RegisterPasswordPolicy(p); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+}
+
+func TestPasswordPolicy_Tags(t *testing.T) {
+	p := verify.PasswordPolicy{MinLength: 10, Upper: true, UserFields: []string{"username", "email"}, Common: true, MinScore: 3}
+	if got, want := p.Tags(), "pwd_min=10,pwd_upper,pwd_user=username email,pwd_common,pwd_score=3"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestPasswordScore(t *testing.T) {
+	cases := []struct {
+		password string
+		max      int
+		min      int
+	}{
+		{"", 0, 0},
+		{"password", 0, 0},
+		{"aaaaaaaa", 1, 0},
+		{"abcdefgh", 1, 0},
+		{"qwertyuiop", 1, 0},
+		{"Password1!", 1, 0},
+		{"kitten42", 4, 2},
+		{"Kx9#mPq2vL", 4, 4},
+		{"correct horse battery staple", 4, 4},
+	}
+	for _, tc := range cases {
+		if got := verify.PasswordScore(tc.password); got < tc.min || got > tc.max {
+			t.Fatalf("%q: expected score in [%d, %d], got %d", tc.password, tc.min, tc.max, got)
+		}
+	}
+}
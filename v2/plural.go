@@ -0,0 +1,168 @@
+package verify
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Plural holds the plural forms of a translation, picked by the cardinal
+// rules of the locale ("1 tag", "2 tags") or, with Ordinal, by its ordinal
+// rules ("1st", "2nd"). {0} is the field name and {1} the tag parameter
+// formatted for the locale. Other is required; forms the locale does not
+// use must be left empty.
+type Plural struct {
+	Zero    string
+	One     string
+	Two     string
+	Few     string
+	Many    string
+	Other   string
+	Ordinal bool
+}
+
+func (p Plural) forms() map[locales.PluralRule]string {
+	forms := make(map[locales.PluralRule]string, 6)
+	for rule, text := range map[locales.PluralRule]string{
+		locales.PluralRuleZero:  p.Zero,
+		locales.PluralRuleOne:   p.One,
+		locales.PluralRuleTwo:   p.Two,
+		locales.PluralRuleFew:   p.Few,
+		locales.PluralRuleMany:  p.Many,
+		locales.PluralRuleOther: p.Other,
+	} {
+		if text != "" {
+			forms[rule] = text
+		}
+	}
+	return forms
+}
+
+// check reports whether p has Other and only forms that trans uses.
+func (p Plural) check(tag string, trans ut.Translator) error {
+	forms := p.forms()
+	if _, ok := forms[locales.PluralRuleOther]; !ok {
+		return fmt.Errorf("plural translation for %q requires Other", tag)
+	}
+	kind, rules := "plural", trans.PluralsCardinal()
+	if p.Ordinal {
+		kind, rules = "ordinal", trans.PluralsOrdinal()
+	}
+	for rule := range forms {
+		if !slices.Contains(rules, rule) {
+			return fmt.Errorf("%s form %s does not exist for locale %q", kind, rule, trans.Locale())
+		}
+	}
+	return nil
+}
+
+// SelfRegisterPluralTranslation registers a custom validation method with a
+// plural-aware translation. messages is keyed by locale; the entry for the
+// Verifier's locale is used.
+//
+//	v.SelfRegisterPluralTranslation("max_tags", map[string]verify.Plural{
+//	    "en": {One: "{0} can have at most {1} tag", Other: "{0} can have at most {1} tags"},
+//	    "zh": {Other: "{0}最多只能有{1}个标签"},
+//	}, maxTags)
+//
+// Nothing is registered if messages cannot be used for the locale.
+func (ver *Verifier) SelfRegisterPluralTranslation(method string, messages map[string]Plural, fn validator.Func) error {
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	p, err := ver.pluralFor(method, messages)
+	if err != nil {
+		return err
+	}
+	if err := ver.validate.RegisterValidation(method, fn); err != nil {
+		return err
+	}
+	return ver.registerPluralLocked(method, p)
+}
+
+// AddPluralTranslation adds a plural-aware translation for an existing
+// validation tag. messages is keyed by locale; the entry for the
+// Verifier's locale is used.
+//
+//	v.AddPluralTranslation("len", map[string]verify.Plural{
+//	    "en": {One: "{0} must be {1} character long", Other: "{0} must be {1} characters long"},
+//	})
+func (ver *Verifier) AddPluralTranslation(method string, messages map[string]Plural) error {
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	p, err := ver.pluralFor(method, messages)
+	if err != nil {
+		return err
+	}
+	return ver.registerPluralLocked(method, p)
+}
+
+// pluralFor returns the entry of messages for the Verifier's locale.
+func (ver *Verifier) pluralFor(method string, messages map[string]Plural) (Plural, error) {
+	p, ok := messages[ver.locale]
+	if !ok {
+		return Plural{}, fmt.Errorf("verify: no %q translation for tag %q", ver.locale, method)
+	}
+	return p, p.check(method, ver.trans)
+}
+
+func (ver *Verifier) registerPluralLocked(method string, p Plural) error {
+	translate := TranslatePlural
+	if p.Ordinal {
+		translate = TranslateOrdinal
+	}
+	return ver.validate.RegisterTranslation(method, ver.trans, RegisterPluralTranslator(method, p), translate)
+}
+
+// RegisterPluralTranslator returns a [validator.RegisterTranslationsFunc]
+// that adds the plural forms of p for the given tag. Translate with
+// [TranslatePlural], or [TranslateOrdinal] if p is ordinal.
+func RegisterPluralTranslator(tag string, p Plural) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		if err := p.check(tag, trans); err != nil {
+			return err
+		}
+		for rule, text := range p.forms() {
+			if err := trans.Add(pluralKey(tag, rule), text, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// TranslatePlural is a [validator.TranslationFunc] that picks the plural form
+// registered by [RegisterPluralTranslator] from the tag parameter using the
+// locale's cardinal plural rules. Non-numeric parameters use Other.
+func TranslatePlural(trans ut.Translator, fe validator.FieldError) string {
+	return translatePlural(trans, fe, cardinalRule)
+}
+
+// TranslateOrdinal is like [TranslatePlural] with the locale's ordinal
+// plural rules, for forms like "1st" and "2nd".
+func TranslateOrdinal(trans ut.Translator, fe validator.FieldError) string {
+	return translatePlural(trans, fe, ordinalRule)
+}
+
+func translatePlural(trans ut.Translator, fe validator.FieldError, ruleFor func(ut.Translator, string) (locales.PluralRule, string, bool)) string {
+	rule, param, ok := ruleFor(trans, fe.Param())
+	if !ok {
+		rule, param = locales.PluralRuleOther, fe.Param()
+	}
+	msg, err := trans.T(pluralKey(fe.Tag(), rule), fe.Field(), param)
+	if err != nil {
+		msg, err = trans.T(pluralKey(fe.Tag(), locales.PluralRuleOther), fe.Field(), param)
+	}
+	if err != nil {
+		return Translate(trans, fe)
+	}
+	return msg
+}
+
+func pluralKey(tag string, rule locales.PluralRule) string {
+	return tag + "-plural-" + rule.String()
+}
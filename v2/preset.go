@@ -0,0 +1,135 @@
+package verify
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Preset is a named, reusable set of tags with its own translations. Define
+// presets once as Go values and share them across services:
+//
+//	var Password = verify.Preset{
+//	    Name:        "password",
+//	    Tags:        "required,min=8,max=32,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+//	    Description: "8–32 characters with a digit and an uppercase letter",
+//	    Messages: map[string]string{
+//	        "zh": "{0}须为8到32位，且包含数字和大写字母",
+//	        "en": "{0} must be 8 to 32 characters with a digit and an uppercase letter",
+//	    },
+//	}
+//
+//	v := verify.MustNew(verify.WithPresets(Password))
+//
+//	type SignUp struct {
+//	    Password string `json:"password" binding:"password"`
+//	}
+type Preset struct {
+	Name        string
+	Tags        string
+	Description string
+	// Messages is keyed by locale. {0} is the field name and {1} the
+	// parameter of the inner tag that failed. Without a message for the
+	// Verifier's locale, a generic "{0} is invalid" is used.
+	Messages map[string]string
+}
+
+// WithPresets registers presets, see [Verifier.RegisterPresets].
+func WithPresets(presets ...Preset) Option {
+	return func(c *config) { c.presets = append(c.presets, presets...) }
+}
+
+// RegisterPreset registers tags under the alias name, translated with the
+// message for the Verifier's locale instead of the failed inner tag's.
+//
+//	v.RegisterPreset("username", "required,min=3,max=20,alphanum", map[string]string{
+//	    "zh": "{0}须为3到20位字母或数字",
+//	})
+func (ver *Verifier) RegisterPreset(name, tags string, messages map[string]string) error {
+	return ver.RegisterPresets(Preset{Name: name, Tags: tags, Messages: messages})
+}
+
+// RegisterPresets registers presets as tag aliases. A preset replaces an
+// earlier one with the same name.
+func (ver *Verifier) RegisterPresets(presets ...Preset) error {
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	for _, p := range presets {
+		if err := ver.registerPresetLocked(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ver *Verifier) registerPresetLocked(p Preset) (err error) {
+	if p.Name == "" || p.Tags == "" {
+		return errors.New("verify: preset name and tags must not be empty")
+	}
+	defer func() {
+		// validator panics on restricted aliases and undefined tags.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("verify: preset %q: %v", p.Name, r)
+		}
+	}()
+	_ = ver.validate.Var("", p.Tags)
+	ver.validate.RegisterAlias(p.Name, p.Tags)
+
+	msg, ok := p.Messages[ver.locale]
+	if !ok {
+		msg = presetText(ver.locale)
+	}
+	if err := ver.validate.RegisterTranslation(p.Name, ver.trans, RegisterTranslator(p.Name, msg), translateWithParam); err != nil {
+		return fmt.Errorf("verify: preset %q: %w", p.Name, err)
+	}
+	p.Messages = maps.Clone(p.Messages)
+	if ver.presets == nil {
+		ver.presets = make(map[string]Preset)
+	}
+	ver.presets[p.Name] = p
+	return nil
+}
+
+// Presets returns the registered presets sorted by name, for example to
+// document the rules of an API.
+func (ver *Verifier) Presets() []Preset {
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	out := make([]Preset, 0, len(ver.presets))
+	for _, p := range ver.presets {
+		p.Messages = maps.Clone(p.Messages)
+		out = append(out, p)
+	}
+	slices.SortFunc(out, func(a, b Preset) int { return cmp.Compare(a.Name, b.Name) })
+	return out
+}
+
+// presetTexts are used for presets without a message for the locale.
+var presetTexts = map[string]string{
+	"zh": "{0}格式不正确",
+	"en": "{0} is invalid",
+}
+
+func presetText(locale string) string {
+	if text, ok := presetTexts[locale]; ok {
+		return text
+	}
+	return presetTexts["en"]
+}
+
+// translateWithParam is a [validator.TranslationFunc] passing the field
+// name as {0} and the tag parameter as {1}.
+func translateWithParam(trans ut.Translator, fe validator.FieldError) string {
+	msg, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+	if err != nil {
+		return Translate(trans, fe)
+	}
+	return msg
+}
@@ -0,0 +1,93 @@
+package verify_test
+
+import (
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+var passwordPreset = verify.Preset{
+	Name:        "password",
+	Tags:        "required,min=8,max=32,containsany=0123456789",
+	Description: "8 到 32 位且包含数字",
+	Messages: map[string]string{
+		"zh": "{0}须为8到32位且包含数字",
+		"en": "{0} must be 8 to 32 characters with a digit",
+	},
+}
+
+type SignUp struct {
+	Password string `json:"password" binding:"password"`
+	Nickname string `json:"nickname" binding:"nickname"`
+}
+
+func TestPreset(t *testing.T) {
+	cases := []struct {
+		locale string
+		s      SignUp
+		want   map[string]string
+	}{
+		{"zh", SignUp{Password: "secret123", Nickname: "gopher"}, nil},
+		{"zh", SignUp{Password: "short1", Nickname: "gopher"}, map[string]string{"password": "password须为8到32位且包含数字"}},
+		{"en", SignUp{Password: "nodigits!", Nickname: "gopher"}, map[string]string{"password": "password must be 8 to 32 characters with a digit"}},
+		// No message for the locale.
+		{"en", SignUp{Password: "secret123"}, map[string]string{"nickname": "nickname is invalid"}},
+	}
+	for _, tc := range cases {
+		v := verify.MustNew(verify.WithLocale(tc.locale), verify.WithPresets(passwordPreset))
+		if err := v.RegisterPreset("nickname", "required,max=20", map[string]string{"zh": "{0}须为1到{1}个字符"}); err != nil {
+			t.Fatal(err)
+		}
+		got := v.AllFieldErrors(v.Struct(tc.s))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s %+v: expected %v, got %v", tc.locale, tc.s, tc.want, got)
+		}
+		for k, msg := range tc.want {
+			if got[k] != msg {
+				t.Fatalf("%s %+v: expected %s=%q, got %v", tc.locale, tc.s, k, msg, got)
+			}
+		}
+	}
+}
+
+func TestPreset_Param(t *testing.T) {
+	v := newVerifier(t)
+	if err := v.RegisterPreset("nickname", "required,max=5", map[string]string{"zh": "{0}最多{1}个字符"}); err != nil {
+		t.Fatal(err)
+	}
+	s := struct {
+		Nickname string `json:"nickname" binding:"nickname"`
+	}{"gophers"}
+	if got := v.AllFieldErrors(v.Struct(s))["nickname"]; got != "nickname最多5个字符" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestPreset_Invalid(t *testing.T) {
+	v := newVerifier(t)
+	for _, p := range []verify.Preset{
+		{Name: "", Tags: "required"},
+		{Name: "empty", Tags: ""},
+		{Name: "required", Tags: "min=1"},
+		{Name: "broken", Tags: "required,nosuchtag"},
+	} {
+		if err := v.RegisterPresets(p); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+	if _, err := verify.New(verify.WithPresets(verify.Preset{Name: "x", Tags: "nosuchtag"})); err == nil {
+		t.Fatal("expected New to fail on an invalid preset")
+	}
+}
+
+func TestPresets(t *testing.T) {
+	v := verify.MustNew(verify.WithPresets(passwordPreset, verify.Preset{Name: "code", Tags: "len=6,numeric"}))
+	got := v.Presets()
+	if len(got) != 2 || got[0].Name != "code" || got[1].Name != "password" {
+		t.Fatalf("unexpected presets %+v", got)
+	}
+	got[1].Messages["zh"] = "changed"
+	if v.Presets()[1].Messages["zh"] == "changed" {
+		t.Fatal("Presets must return copies")
+	}
+}
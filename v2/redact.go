@@ -0,0 +1,119 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// redactTag names the strategy hiding the value of a sensitive field
+// wherever verify exposes it: [FieldViolation.Value], the Value method of
+// the field errors returned by [Verifier.StructCtx] and everything built
+// from them. The "sensitive" tag is short for redact:"mask".
+//
+//	type Account struct {
+//	    Password string `json:"password" binding:"required,min=8" sensitive:""`
+//	    IDNumber string `json:"id_number" binding:"required" redact:"hash"`
+//	    Phone    string `json:"phone" binding:"required,e164" redact:"last4"`
+//	}
+//
+// Built-in strategies are "mask" ("***"), "last4" ("*******5678"), "hash"
+// ("sha256:" and 16 hex digits) and "omit" (nil); see [WithRedactor] for
+// others. An unknown strategy masks. Fields nested in a sensitive struct
+// are redacted with it.
+const (
+	redactTag    = "redact"
+	sensitiveTag = "sensitive"
+)
+
+// RedactFunc returns what is exposed instead of a sensitive value.
+type RedactFunc func(v any) any
+
+// Mask returns a [RedactFunc] replacing every character of the value but
+// the first keepFirst and the last keepLast with '*'. Values too short to
+// keep anything are masked entirely.
+//
+//	verify.Mask(3, 4)("13812345678") // "138****5678"
+func Mask(keepFirst, keepLast int) RedactFunc {
+	return func(v any) any {
+		if v == nil {
+			return nil
+		}
+		r := []rune(fmt.Sprint(v))
+		if keepFirst+keepLast >= len(r) {
+			keepFirst, keepLast = 0, 0
+		}
+		for i := keepFirst; i < len(r)-keepLast; i++ {
+			r[i] = '*'
+		}
+		return string(r)
+	}
+}
+
+var builtinRedactors = map[string]RedactFunc{
+	"mask":  func(any) any { return "***" },
+	"last4": Mask(0, 4),
+	"hash": func(v any) any {
+		sum := sha256.Sum256([]byte(fmt.Sprint(v)))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	},
+	"omit": func(any) any { return nil },
+}
+
+// WithRedactor registers a redaction strategy for redact:"name", or
+// replaces a built-in one. Can be repeated.
+//
+//	verify.WithRedactor("phone", verify.Mask(3, 4))
+func WithRedactor(name string, fn RedactFunc) Option {
+	return func(c *config) {
+		if c.redactors == nil {
+			c.redactors = make(map[string]RedactFunc)
+		}
+		c.redactors[name] = fn
+	}
+}
+
+// RegisterRedactor registers a redaction strategy, see [WithRedactor].
+func (ver *Verifier) RegisterRedactor(name string, fn RedactFunc) error {
+	if name == "" || fn == nil {
+		return errors.New("verify: redactor name and function must not be empty")
+	}
+	ver.mu.Lock()
+	defer ver.mu.Unlock()
+
+	redactors := maps.Clone(*ver.redactors.Load())
+	redactors[name] = fn
+	ver.redactors.Store(&redactors)
+	return nil
+}
+
+// Redact returns v as exposed for the field fld: redacted with the
+// strategy of its "redact" or "sensitive" tag, else unchanged. Integrations
+// reporting values of their own use it.
+func (ver *Verifier) Redact(fld reflect.StructField, v any) any {
+	if fn := ver.redactor(fld); fn != nil {
+		return fn(v)
+	}
+	return v
+}
+
+// redactor returns the strategy of fld, or nil if it is not sensitive.
+func (ver *Verifier) redactor(fld reflect.StructField) RedactFunc {
+	name, ok := fld.Tag.Lookup(redactTag)
+	if !ok {
+		if _, ok := fld.Tag.Lookup(sensitiveTag); !ok {
+			return nil
+		}
+		name = "mask"
+	}
+	if fn, ok := (*ver.redactors.Load())[name]; ok {
+		return fn
+	}
+	if fn, ok := builtinRedactors[name]; ok {
+		return fn
+	}
+	return builtinRedactors["mask"]
+}
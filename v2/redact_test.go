@@ -0,0 +1,89 @@
+package verify_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type KYC struct {
+	Password string   `json:"password" binding:"min=8" sensitive:""`
+	IDNumber string   `json:"id_number" binding:"len=18" redact:"hash"`
+	Phone    string   `json:"phone" binding:"e164" redact:"last4"`
+	Backup   []string `json:"backup" binding:"dive,e164" redact:"phone"`
+	Card     struct {
+		Number string `json:"number" binding:"credit_card"`
+	} `json:"card" redact:"omit"`
+	Name string `json:"name" binding:"max=3"`
+}
+
+func TestRedact(t *testing.T) {
+	v := verify.MustNew(verify.WithRedactor("phone", verify.Mask(3, 4)))
+	k := KYC{Password: "hunter2", IDNumber: "1101011990", Phone: "1381234567a", Backup: []string{"1398765432b"}, Name: "alice"}
+	k.Card.Number = "4111"
+	err := v.Struct(&k)
+
+	got := map[string]any{}
+	for _, fv := range v.Errors(err).Violations {
+		got[fv.Field] = fv.Value
+	}
+	want := map[string]any{
+		"password":    "***",
+		"phone":       "*******567a",
+		"backup[0]":   "139****432b",
+		"card.number": nil,
+		"name":        "alice",
+	}
+	for field, value := range want {
+		if got[field] != value {
+			t.Fatalf("%s: expected %v, got %v", field, value, got[field])
+		}
+	}
+	if id, _ := got["id_number"].(string); !strings.HasPrefix(id, "sha256:") || strings.Contains(id, "1101011990") {
+		t.Fatalf("expected a hash, got %v", got["id_number"])
+	}
+
+	// The raw field errors are redacted too, and so is debug output.
+	var valErrs validator.ValidationErrors
+	errors.As(err, &valErrs)
+	for _, fe := range valErrs {
+		if fe.Field() == "password" && fe.Value() != "***" {
+			t.Fatalf("expected a redacted Value, got %v", fe.Value())
+		}
+	}
+	if out := fmt.Sprintf("%+v", v.Errors(err)); strings.Contains(out, "hunter2") || strings.Contains(out, "1381234567a") {
+		t.Fatalf("sensitive value leaked: %s", out)
+	}
+}
+
+func TestMask(t *testing.T) {
+	for _, tc := range []struct {
+		first, last int
+		in, want    string
+	}{
+		{3, 4, "13812345678", "138****5678"},
+		{0, 4, "1234", "****"},
+		{1, 0, "秘密值", "秘**"},
+	} {
+		if got := verify.Mask(tc.first, tc.last)(tc.in); got != tc.want {
+			t.Fatalf("Mask(%d, %d)(%q) = %v, want %q", tc.first, tc.last, tc.in, got, tc.want)
+		}
+	}
+}
+
+type RedactedWarning struct {
+	Phone string `json:"phone" warn:"len=11" redact:"last4" code:"PHONE"`
+}
+
+func TestRedact_Warnings(t *testing.T) {
+	v := newVerifier(t)
+	w := v.Warnings(&RedactedWarning{Phone: "1380013800099"})
+	if len(w) != 1 || w[0].Value != "*********0099" || w[0].Code != "PHONE" {
+		t.Fatalf("expected a redacted warning with its code, got %+v", w)
+	}
+}
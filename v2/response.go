@@ -0,0 +1,78 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrorResponse is the JSON body written by the framework helpers when
+// binding or validating a request fails.
+type ErrorResponse struct {
+	Message  string           `json:"message"`
+	Errors   []FieldViolation `json:"errors,omitempty"`
+	Warnings []FieldViolation `json:"warnings,omitempty"`
+}
+
+// Response converts an error from binding or validating a request into an
+// [ErrorResponse]. Validation errors are translated; JSON syntax and type
+// errors raised while binding become localized messages. Other errors keep
+// their text.
+//
+//	if err := c.ShouldBindJSON(&params); err != nil {
+//	    c.AbortWithStatusJSON(http.StatusBadRequest, v.Response(err))
+//	    return
+//	}
+func (ver *Verifier) Response(err error) ErrorResponse {
+	if errs := ver.Errors(err); errs != nil {
+		return ErrorResponse{Message: errs.Error(), Errors: errs.Violations, Warnings: errs.Warnings}
+	}
+	if errs, ok := errors.AsType[*Errors](err); ok && errs.Len() > 0 {
+		return ErrorResponse{Message: errs.Error(), Errors: errs.Violations, Warnings: errs.Warnings}
+	}
+	if typeErr, ok := errors.AsType[*json.UnmarshalTypeError](err); ok {
+		field := typeErr.Field
+		if field == "" {
+			field = typeErr.Value
+		}
+		fv := FieldViolation{
+			Field:   field,
+			Tag:     "type",
+			Param:   typeErr.Type.String(),
+			Message: strings.ReplaceAll(bindText(ver.locale, "type"), "{0}", field),
+		}
+		return ErrorResponse{Message: fv.Message, Errors: []FieldViolation{fv}}
+	}
+	if _, ok := errors.AsType[*json.SyntaxError](err); ok || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrorResponse{Message: bindText(ver.locale, "syntax")}
+	}
+	if errors.Is(err, io.EOF) {
+		return ErrorResponse{Message: bindText(ver.locale, "empty")}
+	}
+	if err == nil {
+		return ErrorResponse{}
+	}
+	return ErrorResponse{Message: err.Error()}
+}
+
+var bindTexts = map[string]map[string]string{
+	"zh": {
+		"type":   "{0}类型不正确",
+		"syntax": "请求体格式错误",
+		"empty":  "请求体不能为空",
+	},
+	"en": {
+		"type":   "{0} has an invalid type",
+		"syntax": "malformed request body",
+		"empty":  "request body must not be empty",
+	},
+}
+
+// bindText returns a localized bind error text, falling back to English.
+func bindText(locale, key string) string {
+	if texts, ok := bindTexts[locale]; ok {
+		return texts[key]
+	}
+	return bindTexts["en"][key]
+}
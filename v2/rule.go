@@ -0,0 +1,222 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ruleTag is the struct tag holding the expression rules of a field, and
+// the tag of the violations they report.
+const ruleTag = "when"
+
+// Rule is a compiled set of expression rules, for conditions that tags
+// like required_if cannot express. Rules are written in the "when" struct
+// tag, where they are compiled once and cached, or compiled with
+// [CompileRule] for [Verifier.Map]:
+//
+//	type Payment struct {
+//	    Method    string `json:"payment_method" binding:"required,oneof=card cash"`
+//	    Amount    int    `json:"amount" binding:"gte=0"`
+//	    IDCard    string `json:"id_card" when:"payment_method == 'card' && amount > 1000 => this != '' | '大额刷卡须填写身份证'"`
+//	    HolderAge int    `json:"holder_age" when:"id_card != '' => this >= 18 | '持卡人须年满18岁'"`
+//	}
+//
+// A rule is an assertion, optionally preceded by a condition and "=>",
+// and optionally followed by "|" and a message; several rules are
+// separated by ";". Expressions support literals, lists ['a', 'b'],
+// == != < <= > >= in, && || ! (or and/or/not), + - * / %, and the
+// functions len, empty, contains, matches, lower, upper and now. Names
+// refer to sibling fields by tag name or Go name; "this" is the field
+// itself, "$parent" the struct holding the field's struct, and "$root" the
+// validated value. Dotted paths reach nested fields and map entries.
+//
+// A failed assertion is a violation of the field with the tag "when" and
+// the rule as parameter, translated with the rule's message or else the
+// translation of "when". Rules on a field that failed its tags are skipped.
+type Rule struct {
+	src     string
+	clauses []*ruleClause
+}
+
+type ruleClause struct {
+	src     string // rule without its message
+	message string
+	cond    expr // nil if unconditional
+	assert  expr
+}
+
+// CompileRule compiles rules written in the syntax of the "when" tag.
+func CompileRule(src string) (*Rule, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("verify: rule %q: %w", src, err)
+	}
+	p := &parser{src: src, toks: toks}
+	clauses, err := p.parseRules()
+	if err != nil {
+		return nil, fmt.Errorf("verify: rule %q: %w", src, err)
+	}
+	return &Rule{src: src, clauses: clauses}, nil
+}
+
+// MustCompileRule is like [CompileRule] but panics on error.
+func MustCompileRule(src string) *Rule {
+	r, err := CompileRule(src)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the source of the rule.
+func (r *Rule) String() string { return r.src }
+
+// check returns the first clause whose condition holds and assertion
+// fails, or nil.
+func (r *Rule) check(env *ruleEnv) (*ruleClause, error) {
+	for _, c := range r.clauses {
+		if c.cond != nil {
+			ok, err := evalBool(c.cond, env)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.src, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		ok, err := evalBool(c.assert, env)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.src, err)
+		}
+		if !ok {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+var ruleCache sync.Map // tag value → *Rule or error
+
+func compileTag(src string) (*Rule, error) {
+	if cached, ok := ruleCache.Load(src); ok {
+		if err, ok := cached.(error); ok {
+			return nil, err
+		}
+		return cached.(*Rule), nil
+	}
+	r, err := CompileRule(src)
+	if err != nil {
+		ruleCache.Store(src, err)
+		return nil, err
+	}
+	ruleCache.Store(src, r)
+	return r, nil
+}
+
+var ruleTexts = map[string]string{
+	"zh": "{0}不满足规则",
+	"en": "{0} does not satisfy its rule",
+}
+
+func ruleText(locale string) string {
+	if text, ok := ruleTexts[locale]; ok {
+		return text
+	}
+	return ruleTexts["en"]
+}
+
+// runRules evaluates the "when" rules of the fields of s and merges their
+// violations into err, the result of the tag validation.
+func (ver *Verifier) runRules(s any, err error) error {
+	if !hasStructTag(reflect.TypeOf(s), ruleTag) {
+		return err
+	}
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if err != nil && !ok {
+		return err
+	}
+	failed := make(map[string]bool, len(valErrs))
+	for _, fe := range valErrs {
+		failed[fe.StructNamespace()] = true
+	}
+
+	root := reflect.ValueOf(s)
+	var ruleErr error
+	ver.walkFields(s, func(n *fieldNode) bool {
+		src, ok := n.field.Tag.Lookup(ruleTag)
+		if !ok || failed[n.structNs] {
+			return true
+		}
+		r, err := compileTag(src)
+		if err == nil {
+			env := &ruleEnv{ver: ver, this: n.value, sibling: n.parent, parent: n.outer, root: root}
+			var c *ruleClause
+			if c, err = r.check(env); c != nil {
+				valErrs = append(valErrs, newRuleError(n.ns, n.structNs, n.value, c))
+			}
+		}
+		if err != nil {
+			ruleErr = fmt.Errorf("verify: when rule on %s: %w", n.ns, err)
+			return false
+		}
+		return true
+	})
+	if ruleErr != nil {
+		return ruleErr
+	}
+	if len(valErrs) == 0 {
+		return nil
+	}
+	return valErrs
+}
+
+// runMapRules evaluates the [*Rule] values of rules against data, a map
+// nested in parent under root, and stores violations in out like
+// [validator.Validate.ValidateMap] does.
+func (ver *Verifier) runMapRules(root, parent, data reflect.Value, ns string, rules map[string]any, out map[string]any) {
+	for field, rule := range rules {
+		switch rule := rule.(type) {
+		case *Rule:
+			if _, ok := out[field]; ok {
+				continue
+			}
+			this, _ := ver.member(data, field)
+			env := &ruleEnv{ver: ver, this: this, sibling: data, parent: parent, root: root}
+			c, err := rule.check(env)
+			switch {
+			case err != nil:
+				out[field] = fmt.Errorf("verify: when rule on %s: %w", ns+field, err)
+			case c != nil:
+				out[field] = validator.ValidationErrors{newRuleError(ns+field, ns+field, this, c)}
+			}
+		case map[string]any:
+			nested, ok := indirect(data).Interface().(map[string]any)
+			if !ok {
+				continue
+			}
+			child, ok := nested[field].(map[string]any)
+			if !ok {
+				continue
+			}
+			errs, _ := out[field].(map[string]any)
+			if errs == nil {
+				errs = make(map[string]any)
+			}
+			ver.runMapRules(root, data, reflect.ValueOf(child), ns+field+".", rule, errs)
+			if len(errs) > 0 {
+				out[field] = errs
+			}
+		}
+	}
+}
+
+// newRuleError returns the violation of clause c by the value v at ns.
+func newRuleError(ns, structNs string, v reflect.Value, c *ruleClause) *fieldError {
+	fe := newPathError(ns, structNs, ruleTag, c.src, v, c.message)
+	fe.fixed = c.message != ""
+	return fe
+}
@@ -0,0 +1,114 @@
+package verify_test
+
+import (
+	"strings"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Payment struct {
+	Method    string `json:"payment_method" binding:"required,oneof=card cash"`
+	Amount    int    `json:"amount" binding:"gte=0"`
+	IDCard    string `json:"id_card" when:"payment_method == 'card' && amount > 1000 => this != '' | '大额刷卡须填写身份证'"`
+	HolderAge int    `json:"holder_age" when:"id_card != '' => this >= 18 | '持卡人须年满18岁'"`
+}
+
+func TestRule_Struct(t *testing.T) {
+	v := newVerifier(t)
+	cases := []struct {
+		p    Payment
+		want map[string]string
+	}{
+		{Payment{Method: "cash", Amount: 5000}, nil},
+		{Payment{Method: "card", Amount: 500}, nil},
+		{Payment{Method: "card", Amount: 5000}, map[string]string{"id_card": "大额刷卡须填写身份证"}},
+		{Payment{Method: "card", Amount: 5000, IDCard: "110101", HolderAge: 16}, map[string]string{"holder_age": "持卡人须年满18岁"}},
+		{Payment{Method: "card", Amount: 5000, IDCard: "110101", HolderAge: 30}, nil},
+	}
+	for _, tc := range cases {
+		got := v.AllFieldErrors(v.Struct(tc.p))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%+v: expected %v, got %v", tc.p, tc.want, got)
+		}
+		for k, msg := range tc.want {
+			if got[k] != msg {
+				t.Fatalf("%+v: expected %s=%q, got %v", tc.p, k, msg, got)
+			}
+		}
+	}
+
+	errs := v.Errors(v.Struct(Payment{Method: "card", Amount: 5000}))
+	if fv := errs.Violations[0]; fv.Tag != "when" || fv.Param != "payment_method == 'card' && amount > 1000 => this != ''" {
+		t.Fatalf("unexpected violation %+v", fv)
+	}
+}
+
+type OrderLine struct {
+	SKU   string `json:"sku"`
+	Price int    `json:"price" when:"this <= $parent.limit && $root.currency in ['CNY', 'USD']; len($root.lines) <= 3"`
+}
+
+type Order struct {
+	Currency string      `json:"currency"`
+	Limit    int         `json:"limit"`
+	Lines    []OrderLine `json:"lines"`
+	Code     string      `json:"code" when:"matches(lower(this), '^[a-z]{3}$')"`
+}
+
+func TestRule_ParentAndRoot(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	o := Order{Currency: "CNY", Limit: 100, Code: "ABC", Lines: []OrderLine{{SKU: "a", Price: 50}, {SKU: "b", Price: 150}}}
+	got := v.AllFieldErrors(v.Struct(o))
+	if len(got) != 1 || got["lines[1].price"] != "price does not satisfy its rule" {
+		t.Fatalf("unexpected errors %v", got)
+	}
+
+	o.Lines[1].Price, o.Currency, o.Code = 10, "EUR", "ab1"
+	got = v.AllFieldErrors(v.Struct(o))
+	if len(got) != 3 || got["code"] == "" {
+		t.Fatalf("unexpected errors %v", got)
+	}
+}
+
+func TestRule_Errors(t *testing.T) {
+	for _, src := range []string{"", "a ==", "a => ", "this > 1 | 2", "foo(a)", "$nope.a", "'open", "matches(this, b)"} {
+		if _, err := verify.CompileRule(src); err == nil {
+			t.Fatalf("%q: expected compile error", src)
+		}
+	}
+
+	type Bad struct {
+		Name string `json:"name" when:"this > 3"`
+	}
+	err := newVerifier(t).Struct(Bad{Name: "x"})
+	if err == nil || !strings.Contains(err.Error(), "cannot compare string and number") {
+		t.Fatalf("expected evaluation error, got %v", err)
+	}
+}
+
+func TestRule_Map(t *testing.T) {
+	v := newVerifier(t)
+	rules := map[string]any{
+		"method": "required,oneof=card cash",
+		"id_card": verify.MustCompileRule(
+			"method == 'card' => !empty(this) | '刷卡须填写身份证'"),
+		"holder": map[string]any{
+			"age": verify.MustCompileRule("$parent.method != 'card' || this >= 18"),
+		},
+	}
+	data := map[string]any{"method": "card", "holder": map[string]any{"age": 16}}
+	got := v.AllMapErrors(v.Map(data, rules))
+	if got["id_card"] != "刷卡须填写身份证" {
+		t.Fatalf("unexpected errors %v", got)
+	}
+	nested, ok := v.Map(data, rules)["holder"].(map[string]any)
+	if !ok || len(v.AllMapErrors(nested)) != 1 {
+		t.Fatalf("expected nested violation, got %v", v.Map(data, rules))
+	}
+
+	data = map[string]any{"method": "cash", "holder": map[string]any{"age": 16}}
+	if out := v.Map(data, rules); len(out) != 0 {
+		t.Fatalf("expected no errors, got %v", out)
+	}
+}
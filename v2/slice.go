@@ -0,0 +1,153 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// SliceOptions configures [Verifier.StructSlice].
+type SliceOptions struct {
+	// Workers is the number of elements validated concurrently.
+	// Default: runtime.GOMAXPROCS(0).
+	Workers int
+	// MaxErrors stops dispatching elements once this many invalid rows were
+	// found. Rows already in flight are still reported. Default: no limit.
+	MaxErrors int
+	// Key prefixes the keys returned by [SliceResult.Errors]. Default: "rows".
+	Key string
+}
+
+// RowError holds the violations of one invalid element.
+type RowError struct {
+	Index  int     // zero-based slice index
+	Row    int     // one-based row number
+	Errors *Errors // fields are relative to the element
+}
+
+// SliceResult summarizes a batch validation.
+type SliceResult struct {
+	Total   int        // elements in the slice
+	Checked int        // elements validated; less than Total if stopped early
+	Valid   int        // valid elements
+	Invalid int        // invalid elements
+	Rows    []RowError // invalid elements ordered by index
+	key     string
+}
+
+// Stopped reports whether validation stopped before checking every element.
+func (r *SliceResult) Stopped() bool { return r.Checked < r.Total }
+
+// Errors returns all violations keyed as "rows[i].field".
+func (r *SliceResult) Errors() map[string]string {
+	if len(r.Rows) == 0 {
+		return nil
+	}
+	out := make(map[string]string)
+	for _, row := range r.Rows {
+		for _, fv := range row.Errors.Violations {
+			out[fmt.Sprintf("%s[%d].%s", r.key, row.Index, fv.Field)] = fv.Message
+		}
+	}
+	return out
+}
+
+// StructSlice validates every struct (or struct pointer) element of items
+// in parallel, including async checks. Validation errors are reported per
+// row in the result; the returned error is only set for failures to
+// validate, such as a non-slice argument or a cancelled context. Nil
+// elements are skipped, as by "dive", and counted as valid.
+//
+//	res, err := v.StructSlice(ctx, rows, verify.SliceOptions{Workers: 8, MaxErrors: 100})
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("%d valid, %d invalid", res.Valid, res.Invalid)
+//	for key, msg := range res.Errors() {
+//	    log.Printf("%s: %s", key, msg) // rows[3].email: email必须是一个有效的邮箱
+//	}
+func (ver *Verifier) StructSlice(ctx context.Context, items any, opts SliceOptions) (*SliceResult, error) {
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("verify: StructSlice expects a slice, got %T", items)
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	res := &SliceResult{Total: rv.Len(), key: opts.Key}
+	if res.key == "" {
+		res.key = "rows"
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		invalid  atomic.Int64
+		failures []error
+		next     = make(chan int)
+		wg       sync.WaitGroup
+	)
+	for range min(workers, res.Total) {
+		wg.Go(func() {
+			for i := range next {
+				var err error
+				if elem := rv.Index(i); !isNilElem(elem) {
+					err = ver.StructCtx(ctx, elem.Interface())
+				}
+				errs := ver.Errors(err)
+
+				mu.Lock()
+				res.Checked++
+				switch {
+				case errs != nil:
+					res.Rows = append(res.Rows, RowError{Index: i, Row: i + 1, Errors: errs})
+					invalid.Add(1)
+				case err != nil:
+					failures = append(failures, fmt.Errorf("%s[%d]: %w", res.key, i, err))
+					cancel()
+				default:
+					res.Valid++
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+dispatch:
+	for i := range res.Total {
+		if opts.MaxErrors > 0 && invalid.Load() >= int64(opts.MaxErrors) {
+			break
+		}
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	res.Invalid = len(res.Rows)
+	slices.SortFunc(res.Rows, func(a, b RowError) int { return a.Index - b.Index })
+	return res, nil
+}
+
+// isNilElem reports whether v is a nil pointer or interface.
+func isNilElem(v reflect.Value) bool {
+	return (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()
+}
@@ -0,0 +1,89 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type ImportRow struct {
+	Name  string `json:"name" binding:"required,min=2"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+func importRows(n int) []ImportRow {
+	rows := make([]ImportRow, n)
+	for i := range rows {
+		rows[i] = ImportRow{Name: "alice", Email: "a@b.com"}
+	}
+	return rows
+}
+
+func TestStructSlice(t *testing.T) {
+	v := newVerifier(t)
+	rows := importRows(100)
+	rows[3].Email = "bad"
+	rows[42].Name = ""
+
+	res, err := v.StructSlice(context.Background(), rows, verify.SliceOptions{Workers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 100 || res.Valid != 98 || res.Invalid != 2 || res.Stopped() {
+		t.Fatalf("unexpected summary %+v", res)
+	}
+	if res.Rows[0].Index != 3 || res.Rows[0].Row != 4 || res.Rows[1].Index != 42 {
+		t.Fatalf("unexpected rows %+v", res.Rows)
+	}
+	all := res.Errors()
+	if _, ok := all["rows[3].email"]; !ok {
+		t.Fatalf("expected rows[3].email, got %v", all)
+	}
+	if _, ok := all["rows[42].name"]; !ok {
+		t.Fatalf("expected rows[42].name, got %v", all)
+	}
+}
+
+func TestStructSlice_MaxErrors(t *testing.T) {
+	v := newVerifier(t)
+	rows := make([]*ImportRow, 1000)
+	for i := range rows {
+		rows[i] = &ImportRow{Name: "x"}
+	}
+
+	res, err := v.StructSlice(context.Background(), rows, verify.SliceOptions{Workers: 1, MaxErrors: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Stopped() || res.Invalid < 5 || res.Checked > 10 {
+		t.Fatalf("expected early stop, got checked=%d invalid=%d", res.Checked, res.Invalid)
+	}
+}
+
+func TestStructSlice_NilElements(t *testing.T) {
+	v := newVerifier(t)
+	rows := []*ImportRow{{Name: "alice", Email: "a@b.com"}, nil, {Name: "b"}}
+
+	res, err := v.StructSlice(context.Background(), rows, verify.SliceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Checked != 3 || res.Valid != 2 || res.Invalid != 1 || res.Rows[0].Index != 2 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestStructSlice_Errors(t *testing.T) {
+	v := newVerifier(t)
+	if _, err := v.StructSlice(context.Background(), ImportRow{}, verify.SliceOptions{}); err == nil {
+		t.Fatal("expected error for non-slice")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := v.StructSlice(ctx, importRows(10), verify.SliceOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
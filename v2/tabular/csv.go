@@ -0,0 +1,42 @@
+package tabular
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadCSV decodes and validates CSV data whose first record is the header.
+// A leading UTF-8 byte order mark, as written by Excel, is ignored.
+func (im *Importer[T]) ReadCSV(ctx context.Context, r io.Reader) (*Result[T], error) {
+	cr := csv.NewReader(skipBOM(r))
+	cr.FieldsPerRecord = -1
+	var rows [][]string
+	var lines []int
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tabular: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		rows = append(rows, rec)
+		lines = append(lines, line)
+	}
+	return im.ReadRows(ctx, rows, lines)
+}
+
+func skipBOM(r io.Reader) io.Reader {
+	bom := []byte{0xEF, 0xBB, 0xBF}
+	head := make([]byte, len(bom))
+	n, _ := io.ReadFull(r, head)
+	if bytes.Equal(head[:n], bom) {
+		return r
+	}
+	return io.MultiReader(bytes.NewReader(head[:n]), r)
+}
@@ -0,0 +1,152 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Issue is one problem found in an imported cell or row.
+type Issue struct {
+	Line    int    // one-based sheet row number
+	Column  string // column letter, empty if the field has no column
+	Header  string // header label of the column
+	Value   string // original cell value, redacted for sensitive fields
+	Field   string // Go field namespace, e.g. "Email"
+	Message string // localized message
+}
+
+// Report lists the issues of an import.
+type Report struct {
+	Header  []string
+	Issues  []Issue
+	Total   int // data rows
+	Valid   int // rows without issues
+	Invalid int // rows with at least one issue
+
+	locale string
+	lines  []int      // sheet row number of each data row
+	cells  [][]string // original cells of each data row
+}
+
+// WriteCSV writes the issues as CSV with the columns row, column, header,
+// value and message, titled in the Verifier's locale.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		text(r.locale, "row", ""), text(r.locale, "column", ""), text(r.locale, "header", ""),
+		text(r.locale, "value", ""), text(r.locale, "message", ""),
+	}); err != nil {
+		return err
+	}
+	for _, issue := range r.Issues {
+		if err := cw.Write([]string{
+			strconv.Itoa(issue.Line), issue.Column, issue.Header, issue.Value, issue.Message,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteXLSX writes the imported sheet back as XLSX with invalid cells
+// highlighted and commented, plus a trailing column listing the messages
+// of each row.
+func (r *Report) WriteXLSX(w io.Writer) error {
+	f := excelize.NewFile()
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+
+	msgCol := len(r.Header)
+	for _, cells := range r.cells {
+		msgCol = max(msgCol, len(cells))
+	}
+	header := make([]any, msgCol+1)
+	for i, h := range r.Header {
+		header[i] = h
+	}
+	header[msgCol] = text(r.locale, "message", "")
+	if err := f.SetSheetRow(sheet, "A1", &header); err != nil {
+		return err
+	}
+	for i, cells := range r.cells {
+		row := make([]any, len(cells))
+		for j, c := range cells {
+			row[j] = c
+		}
+		if err := f.SetSheetRow(sheet, cellName(0, r.lines[i]), &row); err != nil {
+			return err
+		}
+	}
+
+	style, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFC7CE"}},
+	})
+	if err != nil {
+		return err
+	}
+	rowMsgs := make(map[int][]string)
+	for _, issue := range r.Issues {
+		rowMsgs[issue.Line] = append(rowMsgs[issue.Line], issue.Message)
+		if issue.Column == "" {
+			continue
+		}
+		cell := issue.Column + strconv.Itoa(issue.Line)
+		if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+			return err
+		}
+		if err := f.AddComment(sheet, excelize.Comment{
+			Cell:      cell,
+			Author:    "verify",
+			Paragraph: []excelize.RichTextRun{{Text: issue.Message}},
+		}); err != nil {
+			return err
+		}
+	}
+	for line, msgs := range rowMsgs {
+		if err := f.SetCellStr(sheet, cellName(msgCol, line), strings.Join(msgs, "; ")); err != nil {
+			return err
+		}
+	}
+	return f.Write(w)
+}
+
+func cellName(col, line int) string {
+	return ColumnName(col) + strconv.Itoa(line)
+}
+
+var texts = map[string]map[string]string{
+	"zh": {
+		"invalid": "%s格式不正确",
+		"row":     "行号",
+		"column":  "列",
+		"header":  "表头",
+		"value":   "原始值",
+		"message": "错误信息",
+	},
+	"en": {
+		"invalid": "%s has an invalid format",
+		"row":     "Row",
+		"column":  "Column",
+		"header":  "Header",
+		"value":   "Value",
+		"message": "Message",
+	},
+}
+
+// text returns a localized report text, falling back to English.
+func text(locale, key, arg string) string {
+	t, ok := texts[locale]
+	if !ok {
+		t = texts["en"]
+	}
+	if strings.Contains(t[key], "%s") {
+		return fmt.Sprintf(t[key], arg)
+	}
+	return t[key]
+}
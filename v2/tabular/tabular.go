@@ -0,0 +1,351 @@
+// Package tabular decodes and validates spreadsheet imports (CSV, XLSX)
+// row by row and reports violations by row and column.
+//
+//	im, err := tabular.New[UserRow](v)
+//	res, err := im.ReadXLSX(ctx, file)
+//	if err != nil {
+//	    return err
+//	}
+//	if res.Report.Invalid > 0 {
+//	    return res.Report.WriteXLSX(w) // original sheet with invalid cells annotated
+//	}
+//	save(res.Valid())
+//
+// Header columns map to struct fields through the "col" tag, or by
+// matching the header label against the JSON name or field name:
+//
+//	type UserRow struct {
+//	    Name  string    `col:"姓名" json:"name" binding:"required,min=2"`
+//	    Email string    `col:"邮箱" json:"email" binding:"required,email"`
+//	    Born  time.Time `json:"born"` // matches a "born" or "Born" header
+//	}
+package tabular
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+// Option configures an [Importer].
+type Option func(*options)
+
+type options struct {
+	workers     int
+	timeLayouts []string
+	sheet       string
+}
+
+// WithWorkers sets how many rows are validated concurrently.
+// Default: runtime.GOMAXPROCS(0).
+func WithWorkers(n int) Option {
+	return func(o *options) { o.workers = n }
+}
+
+// WithTimeLayouts sets the layouts tried when decoding time.Time cells.
+// Default: time.DateOnly, time.DateTime, time.RFC3339.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(o *options) { o.timeLayouts = layouts }
+}
+
+// WithSheet selects the XLSX sheet to read. Default: the first sheet.
+func WithSheet(name string) Option {
+	return func(o *options) { o.sheet = name }
+}
+
+// Importer decodes rows into T and validates them with a [verify.Verifier].
+// It is safe for concurrent use.
+type Importer[T any] struct {
+	ver    *verify.Verifier
+	opts   options
+	fields []fieldSpec
+}
+
+type fieldSpec struct {
+	index    int
+	name     string   // Go field name
+	labels   []string // header labels matched case-insensitively
+	explicit bool     // label comes from the "col" tag and must be present
+}
+
+// New creates an [Importer] for the struct type T.
+func New[T any](ver *verify.Verifier, opts ...Option) (*Importer[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tabular: %s is not a struct", t)
+	}
+	im := &Importer[T]{
+		ver:  ver,
+		opts: options{timeLayouts: []string{time.DateOnly, time.DateTime, time.RFC3339}},
+	}
+	for _, opt := range opts {
+		opt(&im.opts)
+	}
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if !fld.IsExported() {
+			continue
+		}
+		spec := fieldSpec{index: i, name: fld.Name}
+		if col, ok := fld.Tag.Lookup("col"); ok {
+			if col == "-" {
+				continue
+			}
+			spec.labels, spec.explicit = []string{col}, true
+		} else {
+			spec.labels = []string{fld.Name}
+			if name := verify.JSONTagName(fld); name != "" {
+				spec.labels = append(spec.labels, name)
+			}
+		}
+		im.fields = append(im.fields, spec)
+	}
+	return im, nil
+}
+
+// Row is one decoded data row.
+type Row[T any] struct {
+	Line  int  // one-based sheet row number; the header is row 1
+	Value T    // decoded value, zero fields where cells failed to decode
+	Valid bool // whether the row decoded and validated without issues
+}
+
+// Result is the outcome of an import.
+type Result[T any] struct {
+	Rows   []Row[T]
+	Report *Report
+}
+
+// Valid returns the values of the valid rows in sheet order.
+func (r *Result[T]) Valid() []T {
+	out := make([]T, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		if row.Valid {
+			out = append(out, row.Value)
+		}
+	}
+	return out
+}
+
+// ReadRows decodes and validates rows whose first element is the header.
+// lines holds the sheet row number of each row and may be nil when rows
+// are consecutive from row 1. Empty rows are skipped.
+func (im *Importer[T]) ReadRows(ctx context.Context, rows [][]string, lines []int) (*Result[T], error) {
+	if len(rows) == 0 {
+		return nil, errors.New("tabular: missing header row")
+	}
+	if lines == nil {
+		lines = make([]int, len(rows))
+		for i := range lines {
+			lines[i] = i + 1
+		}
+	}
+	header := trimAll(rows[0])
+	columns, err := im.mapColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Header: header, locale: im.ver.Locale()}
+	res := &Result[T]{Report: report}
+	var values []T
+	bad := make(map[[2]int]bool) // {row, column} of cells that failed to decode
+	for i, cells := range rows[1:] {
+		if isEmpty(cells) {
+			continue
+		}
+		line := lines[i+1]
+		var val T
+		rv := reflect.ValueOf(&val).Elem()
+		for fi, col := range columns {
+			if col < 0 || col >= len(cells) {
+				continue
+			}
+			cell := strings.TrimSpace(cells[col])
+			if err := im.decodeCell(rv.Field(im.fields[fi].index), cell); err != nil {
+				bad[[2]int{len(values), col}] = true
+				report.Issues = append(report.Issues, Issue{
+					Line:    line,
+					Column:  ColumnName(col),
+					Header:  header[col],
+					Value:   im.cellValue(fi, cells[col]),
+					Field:   im.fields[fi].name,
+					Message: text(report.locale, "invalid", header[col]),
+				})
+			}
+		}
+		values = append(values, val)
+		res.Rows = append(res.Rows, Row[T]{Line: line, Value: val, Valid: true})
+		report.lines = append(report.lines, line)
+		report.cells = append(report.cells, cells)
+	}
+
+	sliced, err := im.ver.StructSlice(ctx, values, verify.SliceOptions{Workers: im.opts.workers})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range sliced.Rows {
+		for _, fv := range row.Errors.Violations {
+			issue := Issue{Line: res.Rows[row.Index].Line, Field: fv.StructField, Message: fv.Message}
+			if fi := im.fieldIndex(topField(fv.StructField)); fi >= 0 && columns[fi] >= 0 {
+				col := columns[fi]
+				if bad[[2]int{row.Index, col}] {
+					continue
+				}
+				issue.Column, issue.Header = ColumnName(col), header[col]
+				if cells := report.cells[row.Index]; col < len(cells) {
+					issue.Value = im.cellValue(fi, cells[col])
+				}
+			}
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	invalid := make(map[int]bool)
+	for _, issue := range report.Issues {
+		invalid[issue.Line] = true
+	}
+	for i := range res.Rows {
+		res.Rows[i].Valid = !invalid[res.Rows[i].Line]
+	}
+	report.Total, report.Invalid = len(res.Rows), len(invalid)
+	report.Valid = report.Total - report.Invalid
+	slices.SortStableFunc(report.Issues, func(a, b Issue) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return columnNumber(a.Column) - columnNumber(b.Column)
+	})
+	return res, nil
+}
+
+// mapColumns returns the header column of each field, or -1 if unmapped.
+func (im *Importer[T]) mapColumns(header []string) ([]int, error) {
+	columns := make([]int, len(im.fields))
+	for fi, spec := range im.fields {
+		columns[fi] = slices.IndexFunc(header, func(h string) bool {
+			return slices.ContainsFunc(spec.labels, func(l string) bool { return strings.EqualFold(h, l) })
+		})
+		if columns[fi] < 0 && spec.explicit {
+			return nil, fmt.Errorf("tabular: missing column %q", spec.labels[0])
+		}
+	}
+	return columns, nil
+}
+
+func (im *Importer[T]) fieldIndex(name string) int {
+	return slices.IndexFunc(im.fields, func(spec fieldSpec) bool { return spec.name == name })
+}
+
+// cellValue returns cell as reported for the field fields[fi], redacted if
+// the field is sensitive, see [verify.Verifier.Redact].
+func (im *Importer[T]) cellValue(fi int, cell string) string {
+	v, _ := im.ver.Redact(reflect.TypeFor[T]().Field(im.fields[fi].index), cell).(string)
+	return v
+}
+
+func (im *Importer[T]) decodeCell(v reflect.Value, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok && v.Type() != reflect.TypeFor[time.Time]() {
+		return u.UnmarshalText([]byte(cell))
+	}
+	switch v.Interface().(type) {
+	case time.Time:
+		for _, layout := range im.opts.timeLayouts {
+			if t, err := time.ParseInLocation(layout, cell, time.Local); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time %q", cell)
+	case time.Duration:
+		d, err := time.ParseDuration(cell)
+		if err == nil {
+			v.SetInt(int64(d))
+		}
+		return err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// ColumnName returns the spreadsheet letter of a zero-based column index.
+// 0 → "A", 26 → "AA".
+func ColumnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+func columnNumber(name string) int {
+	n := 0
+	for _, r := range name {
+		n = n*26 + int(r-'A') + 1
+	}
+	return n
+}
+
+// topField returns the first segment of a struct namespace.
+func topField(ns string) string {
+	if i := strings.IndexAny(ns, ".["); i >= 0 {
+		return ns[:i]
+	}
+	return ns
+}
+
+func trimAll(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = strings.TrimSpace(c)
+	}
+	return out
+}
+
+func isEmpty(cells []string) bool {
+	return !slices.ContainsFunc(cells, func(c string) bool { return strings.TrimSpace(c) != "" })
+}
@@ -0,0 +1,147 @@
+package tabular_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	verify "github.com/gtkit/verify/v2"
+	"github.com/gtkit/verify/v2/tabular"
+	"github.com/xuri/excelize/v2"
+)
+
+type UserRow struct {
+	Name  string    `col:"姓名" json:"name" binding:"required,min=2"`
+	Email string    `col:"邮箱" json:"email" binding:"required,email"`
+	Age   int       `json:"age" binding:"gte=0,lte=130"`
+	Born  time.Time `json:"born"`
+}
+
+const usersCSV = "\xEF\xBB\xBF姓名,邮箱,age,Born\n" +
+	"alice,a@b.com,30,1994-05-01\n" +
+	"b,bad-email,40,1984-01-01\n" +
+	"\n" +
+	"carol,c@d.com,abc,2000-01-01\n"
+
+func TestReadCSV(t *testing.T) {
+	im, err := tabular.New[UserRow](verify.MustNew(verify.WithLocale("zh")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := im.ReadCSV(context.Background(), strings.NewReader(usersCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep := res.Report
+	if rep.Total != 3 || rep.Valid != 1 || rep.Invalid != 2 {
+		t.Fatalf("unexpected summary total=%d valid=%d invalid=%d", rep.Total, rep.Valid, rep.Invalid)
+	}
+	if got := res.Valid(); len(got) != 1 || got[0].Name != "alice" || got[0].Born.Year() != 1994 {
+		t.Fatalf("unexpected valid rows %+v", got)
+	}
+	want := []struct {
+		line   int
+		column string
+		value  string
+	}{
+		{3, "A", "b"},
+		{3, "B", "bad-email"},
+		{5, "C", "abc"},
+	}
+	if len(rep.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), rep.Issues)
+	}
+	for i, w := range want {
+		got := rep.Issues[i]
+		if got.Line != w.line || got.Column != w.column || got.Value != w.value || got.Message == "" {
+			t.Fatalf("issue %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if rep.Issues[2].Message != "age格式不正确" {
+		t.Fatalf("unexpected decode message %q", rep.Issues[2].Message)
+	}
+
+	var buf bytes.Buffer
+	if err := rep.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || records[0][0] != "行号" || records[1][1] != "A" {
+		t.Fatalf("unexpected CSV report %v", records)
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	for i, row := range [][]any{
+		{"邮箱", "姓名"},
+		{"a@b.com", "alice"},
+		{"nope", "bob"},
+	} {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var in bytes.Buffer
+	if err := f.Write(&in); err != nil {
+		t.Fatal(err)
+	}
+
+	im, err := tabular.New[UserRow](verify.MustNew(verify.WithLocale("en")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := im.ReadXLSX(context.Background(), &in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Report.Issues) != 1 || res.Report.Issues[0].Column != "A" || res.Report.Issues[0].Header != "邮箱" {
+		t.Fatalf("unexpected issues %+v", res.Report.Issues)
+	}
+
+	var out bytes.Buffer
+	if err := res.Report.WriteXLSX(&out); err != nil {
+		t.Fatal(err)
+	}
+	annotated, err := excelize.OpenReader(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := annotated.GetComments(annotated.GetSheetName(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].Cell != "A3" {
+		t.Fatalf("expected a comment on A3, got %+v", comments)
+	}
+	if msg, _ := annotated.GetCellValue(annotated.GetSheetName(0), "C3"); msg == "" {
+		t.Fatal("expected row message in trailing column")
+	}
+}
+
+func TestMissingColumn(t *testing.T) {
+	im, err := tabular.New[UserRow](verify.MustNew())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := im.ReadCSV(context.Background(), strings.NewReader("姓名\nalice\n")); err == nil {
+		t.Fatal("expected missing column error")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for col, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := tabular.ColumnName(col); got != want {
+			t.Fatalf("ColumnName(%d) = %q, want %q", col, got, want)
+		}
+	}
+}
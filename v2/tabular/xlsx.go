@@ -0,0 +1,29 @@
+package tabular
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadXLSX decodes and validates an XLSX sheet whose first row is the
+// header. The sheet is selected with [WithSheet], defaulting to the first.
+func (im *Importer[T]) ReadXLSX(ctx context.Context, r io.Reader) (*Result[T], error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("tabular: %w", err)
+	}
+	defer f.Close()
+
+	sheet := im.opts.sheet
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("tabular: %w", err)
+	}
+	return im.ReadRows(ctx, rows, nil)
+}
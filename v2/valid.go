@@ -0,0 +1,113 @@
+package verify
+
+import (
+	"context"
+	"reflect"
+)
+
+// Defaulter is implemented by values that fill in defaults before they
+// are validated by [Check] or [NewValid].
+type Defaulter interface {
+	SetDefaults()
+}
+
+// Normalizer is implemented by values that normalize themselves, such as
+// trimming or lowercasing, before they are validated by [Check] or
+// [NewValid]. Normalize runs after SetDefaults.
+type Normalizer interface {
+	Normalize()
+}
+
+// Check applies defaults and normalization to value, validates it and
+// returns it. errs is nil if value is valid; otherwise it holds the
+// translated violations, or wraps the error that prevented validation.
+//
+//	params, errs := verify.Check(ctx, v, params)
+//	if errs != nil {
+//	    return errs
+//	}
+//
+// SetDefaults and Normalize are called on value and on its nested struct
+// fields that implement [Defaulter] or [Normalizer] through a pointer. If
+// T is a pointer, the value it points to is modified in place.
+func Check[T any](ctx context.Context, ver *Verifier, value T) (T, *Errors) {
+	prepare(ver, &value)
+	err := ver.StructCtx(ctx, value)
+	if err == nil {
+		return value, nil
+	}
+	if errs := ver.Errors(err); errs != nil {
+		return value, errs
+	}
+	return value, &Errors{cause: err}
+}
+
+// prepare calls the Defaulter and Normalizer hooks of *ptr and of its
+// nested struct fields, parents first.
+func prepare(ver *Verifier, ptr any) {
+	rv := reflect.ValueOf(ptr)
+	for rv.Elem().Kind() == reflect.Pointer && !rv.Elem().IsNil() {
+		rv = rv.Elem()
+	}
+	hook(rv.Interface())
+	ver.walkFields(ptr, func(n *fieldNode) bool {
+		v := n.value
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct && v.CanAddr() && v.Addr().CanInterface() {
+			hook(v.Addr().Interface())
+		}
+		return true
+	})
+}
+
+func hook(ptr any) {
+	if d, ok := ptr.(Defaulter); ok {
+		d.SetDefaults()
+	}
+	if n, ok := ptr.(Normalizer); ok {
+		n.Normalize()
+	}
+}
+
+// Valid holds a value that passed validation. It can only be built by
+// [NewValid] or [MustValid], so a function taking a Valid[T] requires
+// validated input:
+//
+//	func CreateUser(ctx context.Context, in verify.Valid[SignUpParams]) error {
+//	    p := in.Value()
+//	    ...
+//	}
+type Valid[T any] struct {
+	value T
+	ok    bool
+}
+
+// NewValid validates value like [Check] and wraps the result.
+func NewValid[T any](ctx context.Context, ver *Verifier, value T) (Valid[T], *Errors) {
+	value, errs := Check(ctx, ver, value)
+	if errs != nil {
+		return Valid[T]{}, errs
+	}
+	return Valid[T]{value: value, ok: true}, nil
+}
+
+// MustValid is like [NewValid] but panics if value is invalid. Use only in
+// tests and initialization.
+func MustValid[T any](ver *Verifier, value T) Valid[T] {
+	v, errs := NewValid(context.Background(), ver, value)
+	if errs != nil {
+		panic("verify: invalid value: " + errs.Error())
+	}
+	return v
+}
+
+// Value returns the validated value, with defaults and normalization
+// applied. It panics on a zero Valid, which was never validated.
+func (v Valid[T]) Value() T {
+	if !v.ok {
+		panic("verify: Value called on a zero Valid")
+	}
+	return v.value
+}
@@ -0,0 +1,71 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Address struct {
+	City string `json:"city" binding:"required"`
+}
+
+func (a *Address) Normalize() { a.City = strings.TrimSpace(a.City) }
+
+type Account struct {
+	Email   string  `json:"email" binding:"required,email"`
+	Role    string  `json:"role" binding:"oneof=admin member"`
+	Address Address `json:"address"`
+}
+
+func (a *Account) SetDefaults() {
+	if a.Role == "" {
+		a.Role = "member"
+	}
+}
+
+func (a *Account) Normalize() { a.Email = strings.ToLower(strings.TrimSpace(a.Email)) }
+
+func TestCheck(t *testing.T) {
+	v := newVerifier(t)
+	got, errs := verify.Check(context.Background(), v, Account{Email: " A@B.com ", Address: Address{City: "Paris"}})
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	if got.Email != "a@b.com" || got.Role != "member" {
+		t.Fatalf("defaults/normalization not applied: %+v", got)
+	}
+
+	_, errs = verify.Check(context.Background(), v, &Account{Email: "x@y.com", Address: Address{City: "  "}})
+	if errs.Len() != 1 || errs.Map()["address.city"] != "city为必填字段" {
+		t.Fatalf("unexpected errors: %v", errs.Map())
+	}
+
+	_, errs = verify.Check(context.Background(), v, 42)
+	if errs == nil || errs.Len() != 0 || errors.Unwrap(errs) == nil {
+		t.Fatalf("expected wrapped cause, got %v", errs)
+	}
+}
+
+func TestValid(t *testing.T) {
+	v := newVerifier(t)
+	in := verify.MustValid(v, Account{Email: "a@b.com", Address: Address{City: "Paris"}})
+	if in.Value().Role != "member" {
+		t.Fatalf("expected default role, got %+v", in.Value())
+	}
+
+	if _, errs := verify.NewValid(context.Background(), v, Account{}); errs == nil {
+		t.Fatal("expected errors")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on zero Valid")
+		}
+	}()
+	var zero verify.Valid[Account]
+	_ = zero.Value()
+}
@@ -0,0 +1,230 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validatableTag is the tag of violations reported by a Validate method
+// that returned a plain error. Register a translation for it to replace
+// the error text.
+const validatableTag = "validate"
+
+// Validatable is implemented by types with invariants that do not fit in
+// tags. [Verifier.StructCtx] calls Validate on the validated struct and on
+// every value inside it that implements Validatable, after they pass their
+// tags:
+//
+//	type Period struct {
+//	    Start time.Time `json:"start" binding:"required"`
+//	    End   time.Time `json:"end" binding:"required"`
+//	}
+//
+//	func (p Period) Validate(ctx context.Context) error {
+//	    if p.End.Before(p.Start) {
+//	        return errors.New("end must not be before start")
+//	    }
+//	    return nil
+//	}
+//
+// A returned [*Errors] or [validator.ValidationErrors] is merged with its
+// paths re-rooted under the value's path, so "end" reported by the Period
+// at "booking.period" becomes "booking.period.end". Any other error is a
+// violation of the value itself with the tag "validate". If ctx is done
+// when Validate returns an error, StructCtx fails with that error instead.
+//
+// Validate may call StructCtx on its receiver with the ctx it was given;
+// the receiver's own Validate is not called again.
+type Validatable interface {
+	Validate(ctx context.Context) error
+}
+
+var validatableType = reflect.TypeFor[Validatable]()
+
+var validatableTypes sync.Map // reflect.Type → bool
+
+// hasValidatable reports whether t, or any type reachable from it,
+// implements [Validatable]. Results are cached.
+func hasValidatable(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if found, ok := validatableTypes.Load(t); ok {
+		return found.(bool)
+	}
+	found := implementsValidatable(t) || scanFields(t, func(fld reflect.StructField) bool {
+		return implementsValidatable(fld.Type)
+	}, make(map[reflect.Type]bool))
+	validatableTypes.Store(t, found)
+	return found
+}
+
+// implementsValidatable reports whether t, a pointer to it or the type it
+// points to implements [Validatable].
+func implementsValidatable(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Implements(validatableType) || t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(validatableType)
+}
+
+// validatingKey marks the context passed to Validate with the type of its
+// receiver, so validating the receiver again from Validate neither recurses
+// nor calls the Validate methods inside it, which the outer validation
+// calls.
+type validatingKey struct{}
+
+// runValidatables calls the Validate methods of s and of the values inside
+// it that passed their tags, and merges their violations into err.
+func (ver *Verifier) runValidatables(ctx context.Context, s any, err error) error {
+	if !hasValidatable(reflect.TypeOf(s)) {
+		return err
+	}
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if err != nil && !ok {
+		return err
+	}
+
+	// Validate runs on values that passed their tags, so skip every value
+	// holding a failed field.
+	failed := make(map[string]bool)
+	for _, fe := range valErrs {
+		ns := fe.StructNamespace()
+		failed[ns] = true
+		for i := range len(ns) {
+			if ns[i] == '.' || ns[i] == '[' {
+				failed[ns[:i]] = true
+			}
+		}
+	}
+
+	root := reflect.ValueOf(s)
+	for root.Kind() == reflect.Interface || root.Kind() == reflect.Pointer && root.Elem().Kind() == reflect.Pointer {
+		if root.IsNil() {
+			return err
+		}
+		root = root.Elem()
+	}
+	if root.Kind() != reflect.Pointer {
+		// Copy into an addressable value so pointer receivers are found.
+		p := reflect.New(root.Type())
+		p.Elem().Set(root)
+		root = p
+	}
+	if root.IsNil() || root.Elem().Kind() != reflect.Struct {
+		return err
+	}
+	top := root.Elem().Type().Name()
+
+	var added validator.ValidationErrors
+	var callErr error
+	call := func(v reflect.Value, ns, structNs string) {
+		if callErr != nil || failed[structNs] {
+			return
+		}
+		target, ok := asValidatable(v)
+		if !ok {
+			return
+		}
+		fes, err := ver.callValidate(ctx, target, v, ns, structNs)
+		if err != nil {
+			callErr = err
+			return
+		}
+		added = append(added, fes...)
+	}
+
+	if skip, _ := ctx.Value(validatingKey{}).(reflect.Type); skip == root.Type() {
+		return err
+	}
+	call(root, top, top)
+	ver.walkValues(root.Interface(), call)
+	if callErr != nil {
+		return callErr
+	}
+	if len(added) == 0 {
+		return err
+	}
+	return append(valErrs, added...)
+}
+
+// callValidate calls target.Validate and converts its error into field
+// errors rooted at ns.
+func (ver *Verifier) callValidate(ctx context.Context, target Validatable, v reflect.Value, ns, structNs string) ([]validator.FieldError, error) {
+	err := target.Validate(context.WithValue(ctx, validatingKey{}, reflect.TypeOf(target)))
+	if err == nil {
+		return nil, nil
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("verify: validate %s: %w", ns, ctx.Err())
+	}
+
+	errs := ver.Errors(err)
+	if errs == nil {
+		errs, _ = errors.AsType[*Errors](err)
+	}
+	if errs.Len() == 0 {
+		if errs != nil && errs.cause != nil {
+			return nil, fmt.Errorf("verify: validate %s: %w", ns, errs.cause)
+		}
+		return []validator.FieldError{newPathError(ns, structNs, validatableTag, "", v, err.Error())}, nil
+	}
+
+	fes := make([]validator.FieldError, 0, errs.Len())
+	for _, fv := range errs.Violations {
+		structField := fv.StructField
+		if structField == "" {
+			structField = fv.Field
+		}
+		fes = append(fes, newPathError(ns+"."+fv.Field, structNs+"."+structField, fv.Tag, fv.Param, reflect.ValueOf(fv.Value), fv.Message))
+	}
+	return fes, nil
+}
+
+// asValidatable returns the [Validatable] held by v, through a pointer if
+// v is addressable.
+func asValidatable(v reflect.Value) (Validatable, bool) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, false
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		v = v.Addr()
+	}
+	if !v.CanInterface() {
+		return nil, false
+	}
+	target, ok := v.Interface().(Validatable)
+	return target, ok
+}
+
+// newPathError returns a [fieldError] for the value at namespace ns.
+func newPathError(ns, structNs, tag, param string, v reflect.Value, msg string) *fieldError {
+	fe := &fieldError{
+		tag:         tag,
+		ns:          ns,
+		structNs:    structNs,
+		field:       ns[strings.LastIndexByte(ns, '.')+1:],
+		structField: structNs[strings.LastIndexByte(structNs, '.')+1:],
+		param:       param,
+		msg:         msg,
+	}
+	if v.IsValid() {
+		fe.kind, fe.typ = v.Kind(), v.Type()
+		if v.CanInterface() {
+			fe.value = v.Interface()
+		}
+	}
+	return fe
+}
@@ -0,0 +1,126 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Period struct {
+	Start int `json:"start" binding:"required"`
+	End   int `json:"end" binding:"required"`
+}
+
+func (p Period) Validate(context.Context) error {
+	if p.End < p.Start {
+		return errors.New("end must not be before start")
+	}
+	return nil
+}
+
+type Guest struct {
+	Name string `json:"name" binding:"required"`
+	Age  int    `json:"age"`
+}
+
+var validatedGuests int
+
+// Validate reports a violation of a nested field through verify's own
+// error type.
+func (g *Guest) Validate(ctx context.Context) error {
+	validatedGuests++
+	if g.Age < 18 {
+		return &verify.Errors{Violations: []verify.FieldViolation{{
+			Field: "age", StructField: "Age", Tag: "adult", Message: "age must be at least 18",
+		}}}
+	}
+	return nil
+}
+
+type Booking struct {
+	Room   string  `json:"room" binding:"required"`
+	Period Period  `json:"period"`
+	Guests []Guest `json:"guests" binding:"dive"`
+}
+
+var validatedBookings int
+
+// Validate validates its receiver again, which must not recurse.
+func (b *Booking) Validate(ctx context.Context) error {
+	validatedBookings++
+	if len(b.Guests) > 2 {
+		return verify.Default().StructCtx(ctx, b)
+	}
+	return nil
+}
+
+func TestValidatable(t *testing.T) {
+	v := newVerifier(t)
+	b := Booking{
+		Room:   "101",
+		Period: Period{Start: 5, End: 3},
+		Guests: []Guest{{Name: "alice", Age: 30}, {Name: "bob", Age: 12}},
+	}
+	got := v.AllFieldErrors(v.Struct(b))
+	want := map[string]string{
+		"period":        "end must not be before start",
+		"guests[1].age": "age must be at least 18",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, msg := range want {
+		if got[k] != msg {
+			t.Fatalf("expected %s=%q, got %v", k, msg, got)
+		}
+	}
+
+	errs := v.Errors(v.Struct(&b))
+	for _, fv := range errs.Violations {
+		if fv.Field == "guests[1].age" && (fv.Tag != "adult" || fv.StructField != "Guests[1].Age") {
+			t.Fatalf("unexpected violation %+v", fv)
+		}
+	}
+}
+
+func TestValidatable_SkipsFailedTags(t *testing.T) {
+	v := newVerifier(t)
+	b := Booking{Period: Period{Start: 5}, Guests: []Guest{{Age: 1}}}
+	got := v.AllFieldErrors(v.Struct(b))
+	if len(got) != 3 || got["period.end"] != "end为必填字段" || got["guests[0].name"] == "" {
+		t.Fatalf("unexpected errors %v", got)
+	}
+}
+
+func TestValidatable_NoRecursion(t *testing.T) {
+	if err := verify.Init(verify.WithLocale("zh")); err != nil {
+		t.Fatal(err)
+	}
+	validatedBookings, validatedGuests = 0, 0
+	b := Booking{Room: "101", Period: Period{Start: 1, End: 2}, Guests: []Guest{{Name: "a", Age: 20}, {Name: "b", Age: 20}, {Name: "c", Age: 20}}}
+	if err := verify.Default().Struct(&b); err != nil {
+		t.Fatal(err)
+	}
+	if validatedBookings != 1 || validatedGuests != 3 {
+		t.Fatalf("expected one Validate call per value, got %d bookings and %d guests", validatedBookings, validatedGuests)
+	}
+}
+
+type slowRule struct{}
+
+func (slowRule) Validate(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestValidatable_ContextDone(t *testing.T) {
+	v := newVerifier(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := v.StructCtx(ctx, struct{ Rule slowRule }{})
+	if !errors.Is(err, context.Canceled) || v.Errors(err) != nil {
+		t.Fatalf("expected context error, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gtkit/verify/v2/internal/env"
+)
+
+// valueTypes are the type pseudo-tags of [Verifier.Values] and the Go
+// types their parameters are converted to.
+var valueTypes = map[string]reflect.Type{
+	"string": reflect.TypeFor[string](),
+	"int":    reflect.TypeFor[int](),
+	"uint":   reflect.TypeFor[uint](),
+	"float":  reflect.TypeFor[float64](),
+	"bool":   reflect.TypeFor[bool](),
+	"time":   timeType,
+}
+
+var valueTexts = map[string]map[string]string{
+	"zh": {
+		"int":   "{0}必须是整数",
+		"uint":  "{0}必须是非负整数",
+		"float": "{0}必须是数字",
+		"bool":  "{0}必须是布尔值",
+		"time":  "{0}必须是有效的时间",
+	},
+	"en": {
+		"int":   "{0} must be an integer",
+		"uint":  "{0} must be a non-negative integer",
+		"float": "{0} must be a number",
+		"bool":  "{0} must be a boolean",
+		"time":  "{0} must be a valid time",
+	},
+}
+
+func valueText(locale, typ string) string {
+	if texts, ok := valueTexts[locale]; ok {
+		return texts[typ]
+	}
+	return valueTexts["en"][typ]
+}
+
+// Values validates query or form parameters without a struct. Each rule
+// may start with a type pseudo-tag the parameter is converted to before
+// the remaining tags are applied: string (default), int, uint, float,
+// bool or time, which parses RFC 3339 or the layout given as parameter.
+// A "[]" prefix collects repeated keys into a slice; otherwise the first
+// value is used.
+//
+//	values, errs := v.Values(r.URL.Query(), map[string]string{
+//	    "page":  "int,omitempty,min=1",
+//	    "size":  "int,omitempty,max=100",
+//	    "ids":   "[]int,max=20,dive,min=1",
+//	    "since": "time=2006-01-02,omitempty",
+//	    "q":     "required,max=50",
+//	})
+//	if errs != nil {
+//	    return errs // errs.Map(): map[page:page必须是整数]
+//	}
+//	page := values["page"].(int)
+//
+// values holds the converted value of every parameter with a rule, the
+// zero value for absent ones. With omitempty, absent parameters are
+// skipped while present zeros like page=0 are still validated. errs is keyed by parameter name; a
+// parameter that cannot be converted is a violation with its type as tag.
+// Tags like eqfield can refer to other parameters by name.
+func (ver *Verifier) Values(values url.Values, rules map[string]string) (map[string]any, *Errors) {
+	return ver.ValuesCtx(context.Background(), values, rules)
+}
+
+// ValuesCtx is like [Verifier.Values] with a context.
+func (ver *Verifier) ValuesCtx(ctx context.Context, values url.Values, rules map[string]string) (map[string]any, *Errors) {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// The field of a parameter is named P and its index in names; tags
+	// naming other parameters, like eqfield=password, are rewritten to it.
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	toField := func(w string) string {
+		if i, ok := index[w]; ok {
+			return "P" + strconv.Itoa(i)
+		}
+		return w
+	}
+
+	out := make(map[string]any, len(rules))
+	errs := &Errors{}
+	var fields []reflect.StructField
+	var converted []reflect.Value
+	for i, name := range names {
+		typ, layout, tags := splitValueRule(rules[name])
+		slice := strings.HasPrefix(typ, "[]")
+		elem, ok := valueTypes[strings.TrimPrefix(typ, "[]")]
+		if !ok {
+			return nil, &Errors{cause: fmt.Errorf("verify: unknown type %q in rule for %q", typ, name)}
+		}
+		v, err := convertValues(values[name], elem, slice, layout)
+		if err != nil {
+			msg, terr := ver.trans.T(strings.TrimPrefix(typ, "[]"), name)
+			if terr != nil {
+				msg = err.Error()
+			}
+			errs.Violations = append(errs.Violations, FieldViolation{
+				Field:       name,
+				StructField: name,
+				Tag:         strings.TrimPrefix(typ, "[]"),
+				Code:        ver.tagCode(strings.TrimPrefix(typ, "[]")),
+				Param:       layout,
+				Value:       values[name],
+				Message:     msg,
+			})
+			continue
+		}
+		out[name] = v.Interface()
+		// With omitempty an absent parameter is skipped, but a present
+		// zero such as page=0 is still validated.
+		if !slice && slices.Contains(strings.Split(tags, ","), "omitempty") {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			if len(values[name]) == 0 || values[name][0] == "" {
+				p = reflect.Zero(p.Type())
+			}
+			v = p
+		}
+		tag := `json:` + strconv.Quote(name) + ` form:` + strconv.Quote(name) + ` query:` + strconv.Quote(name)
+		if tags != "" {
+			tag += ` binding:` + strconv.Quote(renameTags(tags, toField))
+		}
+		fields = append(fields, reflect.StructField{
+			Name: "P" + strconv.Itoa(i),
+			Type: v.Type(),
+			Tag:  reflect.StructTag(tag),
+		})
+		converted = append(converted, v)
+	}
+
+	// The parameters are validated as the fields of a struct built for
+	// the rules, so that messages name them and cross-field tags work.
+	s := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, v := range converted {
+		s.Field(i).Set(v)
+	}
+	err := ver.validate.StructCtx(ctx, s.Interface())
+	if verrs := ver.Errors(err); verrs != nil {
+		toRule := func(w string) string { return ruleField(w, names) }
+		for _, fv := range verrs.Violations {
+			fv.Field = ruleField(fv.StructField, names)
+			fv.StructField = fv.Field
+			if param := renameParam(fv.Tag, fv.Param, toRule); param != fv.Param {
+				fv.Message = strings.Replace(fv.Message, fv.Param, param, 1)
+				fv.Param = param
+			}
+			errs.Violations = append(errs.Violations, fv)
+		}
+	} else if err != nil {
+		return out, &Errors{cause: err}
+	}
+	if len(errs.Violations) == 0 {
+		return out, nil
+	}
+	return out, errs
+}
+
+// ruleField returns the rule key of a violation of the struct built by
+// [Verifier.ValuesCtx] from its field namespace, keeping dive indexes:
+// "P3[1]" is "ids[1]". Keys may contain dots, like "user.name".
+func ruleField(structNs string, names []string) string {
+	digits := strings.TrimPrefix(structNs, "P")
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(digits)
+	}
+	n, err := strconv.Atoi(digits[:end])
+	if err != nil || n >= len(names) {
+		return structNs
+	}
+	return names[n] + digits[end:]
+}
+
+// fieldParamTags are the tags whose parameter names other fields, with the
+// step between the names among its words: every word of
+// "required_with=a b", every other one of "required_if=a 1 b 2".
+var fieldParamTags = map[string]int{
+	"eqfield": 1, "nefield": 1, "gtfield": 1, "gtefield": 1, "ltfield": 1, "ltefield": 1,
+	"fieldcontains": 1, "fieldexcludes": 1,
+	"required_with": 1, "required_with_all": 1, "required_without": 1, "required_without_all": 1,
+	"excluded_with": 1, "excluded_with_all": 1, "excluded_without": 1, "excluded_without_all": 1,
+	"required_if": 2, "required_unless": 2, "excluded_if": 2, "excluded_unless": 2,
+}
+
+// renameTags applies rename to the field names in the parameters of tags.
+func renameTags(tags string, rename func(string) string) string {
+	parts := strings.Split(tags, ",")
+	for i, part := range parts {
+		alts := strings.Split(part, "|")
+		for j, alt := range alts {
+			if tag, param, ok := strings.Cut(alt, "="); ok {
+				alts[j] = tag + "=" + renameParam(tag, param, rename)
+			}
+		}
+		parts[i] = strings.Join(alts, "|")
+	}
+	return strings.Join(parts, ",")
+}
+
+// renameParam applies rename to the field names in the parameter of tag.
+func renameParam(tag, param string, rename func(string) string) string {
+	step := fieldParamTags[tag]
+	if step == 0 {
+		return param
+	}
+	words := strings.Split(param, " ")
+	for i := 0; i < len(words); i += step {
+		words[i] = rename(words[i])
+	}
+	return strings.Join(words, " ")
+}
+
+// splitValueRule splits "int,min=1" into its type pseudo-tag, the
+// type's parameter and the remaining tags.
+func splitValueRule(rule string) (typ, param, tags string) {
+	first, rest, _ := strings.Cut(rule, ",")
+	name, param, _ := strings.Cut(first, "=")
+	if _, ok := valueTypes[strings.TrimPrefix(name, "[]")]; ok || strings.HasPrefix(name, "[]") {
+		return name, param, rest
+	}
+	return "string", "", rule
+}
+
+// convertValues converts the values of a parameter to typ, or to a slice
+// of typ.
+func convertValues(raw []string, typ reflect.Type, slice bool, layout string) (reflect.Value, error) {
+	if !slice {
+		v := reflect.New(typ).Elem()
+		if len(raw) == 0 || raw[0] == "" {
+			return v, nil
+		}
+		return v, convertValue(v, raw[0], layout)
+	}
+	if len(raw) == 0 {
+		return reflect.Zero(reflect.SliceOf(typ)), nil
+	}
+	s := reflect.MakeSlice(reflect.SliceOf(typ), len(raw), len(raw))
+	for i, r := range raw {
+		if err := convertValue(s.Index(i), r, layout); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+func convertValue(v reflect.Value, raw, layout string) error {
+	if v.Type() == timeType {
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	return env.SetValue(v, raw, ",")
+}
@@ -0,0 +1,104 @@
+package verify_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestValues(t *testing.T) {
+	v := newVerifier(t)
+	rules := map[string]string{
+		"page":   "int,omitempty,min=1",
+		"ids":    "[]int,max=3,dive,min=1",
+		"active": "bool",
+		"since":  "time=2006-01-02,omitempty",
+		"q":      "required,max=10",
+	}
+
+	values, errs := v.Values(url.Values{
+		"page":   {"2"},
+		"ids":    {"1", "2"},
+		"active": {"true"},
+		"since":  {"2024-05-01"},
+		"q":      {"go", "ignored"},
+	}, rules)
+	if errs != nil {
+		t.Fatalf("unexpected errors %v", errs.Map())
+	}
+	if values["page"] != 2 || len(values["ids"].([]int)) != 2 || values["active"] != true ||
+		!values["since"].(time.Time).Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) || values["q"] != "go" {
+		t.Fatalf("unexpected values %v", values)
+	}
+
+	cases := []struct {
+		query url.Values
+		want  map[string]string
+	}{
+		{url.Values{"q": {"go"}}, nil},
+		{url.Values{"q": {"go"}, "page": {"two"}}, map[string]string{"page": "page必须是整数"}},
+		{url.Values{"q": {"go"}, "page": {"0"}}, map[string]string{"page": "page最小只能为1"}},
+		{url.Values{"q": {"go"}, "ids": {"1", "x"}}, map[string]string{"ids": "ids必须是整数"}},
+		{url.Values{"q": {"go"}, "ids": {"1", "0"}}, map[string]string{"ids[1]": "ids[1]最小只能为1"}},
+		{url.Values{"q": {"go"}, "active": {"maybe"}}, map[string]string{"active": "active必须是布尔值"}},
+		{url.Values{"q": {"go"}, "since": {"yesterday"}}, map[string]string{"since": "since必须是有效的时间"}},
+		{url.Values{}, map[string]string{"q": "q为必填字段"}},
+	}
+	for _, tc := range cases {
+		_, errs := v.Values(tc.query, rules)
+		got := errs.Map()
+		if len(got) != len(tc.want) {
+			t.Fatalf("%v: expected %v, got %v", tc.query, tc.want, got)
+		}
+		for k, msg := range tc.want {
+			if got[k] != msg {
+				t.Fatalf("%v: expected %s=%q, got %v", tc.query, k, msg, got)
+			}
+		}
+	}
+}
+
+func TestValues_CrossField(t *testing.T) {
+	v := newVerifier(t)
+	rules := map[string]string{"password": "min=6", "confirm": "eqfield=password"}
+	_, errs := v.Values(url.Values{"password": {"secret1"}, "confirm": {"secret2"}}, rules)
+	if errs == nil || errs.Map()["confirm"] != "confirm必须等于password" {
+		t.Fatalf("expected confirm mismatch, got %v", errs.Map())
+	}
+	if fv := errs.Violations[0]; fv.Param != "password" {
+		t.Fatalf("expected the parameter name as param, got %+v", fv)
+	}
+
+	if _, errs := v.Values(url.Values{"password": {"secret1"}, "confirm": {"secret1"}}, rules); errs != nil {
+		t.Fatalf("expected equal values to pass, got %v", errs.Map())
+	}
+	rules = map[string]string{"kind": "omitempty", "page": "int,required_if=kind list"}
+	if _, errs := v.Values(url.Values{"kind": {"list"}}, rules); errs == nil || errs.Map()["page"] == "" {
+		t.Fatalf("expected page required for kind=list, got %v", errs)
+	}
+}
+
+func TestValues_UnknownType(t *testing.T) {
+	v := newVerifier(t)
+	if _, errs := v.Values(nil, map[string]string{"x": "[]duration"}); errs == nil || errs.Unwrap() == nil {
+		t.Fatalf("expected an error for an unknown type, got %v", errs)
+	}
+}
+
+func TestValues_DottedKeys(t *testing.T) {
+	v := newVerifier(t)
+	_, errs := v.Values(url.Values{"user.name": {"a"}, "user.ids": {"1", "0"}}, map[string]string{
+		"user.name": "min=2",
+		"user.ids":  "[]int,dive,min=1",
+		"page":      "int,required",
+	})
+	var got []string
+	for _, fv := range errs.Violations {
+		got = append(got, fv.Field+" "+fv.StructField)
+	}
+	want := "[page page user.ids[1] user.ids[1] user.name user.name]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("expected %s, got %v", want, got)
+	}
+}
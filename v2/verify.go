@@ -20,11 +20,15 @@ package verify
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-playground/locales/en"
 	"github.com/go-playground/locales/zh"
@@ -32,14 +36,29 @@ import (
 	"github.com/go-playground/validator/v10"
 	enTranslations "github.com/go-playground/validator/v10/translations/en"
 	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+	"github.com/gtkit/goerr"
 )
 
 // Verifier is a concurrency-safe validation instance.
 type Verifier struct {
-	validate *validator.Validate
-	trans    ut.Translator
-	locale   string
-	mu       sync.Mutex // protects runtime registration
+	validate     *validator.Validate
+	trans        ut.Translator
+	locale       string
+	tagNameFunc  func(reflect.StructField) string
+	mu           sync.Mutex // protects runtime registration
+	catalog      atomic.Pointer[catalog]
+	checks       atomic.Pointer[map[string]CheckFunc]
+	codes        atomic.Pointer[codeTable]
+	errorFactory ErrorFactory
+	redactors    atomic.Pointer[map[string]RedactFunc]
+	checkLimit   int
+	checkTimeout time.Duration
+	fieldIndexes sync.Map // reflect.Type → map[string]int, see fieldIndex
+	structLevels sync.Map // reflect.Type → true, see RegisterStructValidation
+	presets      map[string]Preset
+	maxErrors    int
+	logger       *slog.Logger
+	observer     Observer
 }
 
 // ---------- Options ----------
@@ -53,6 +72,23 @@ type config struct {
 	requiredStructEnabled  bool
 	privateFieldValidation bool
 	tagNameFunc            func(reflect.StructField) string
+	checkConcurrency       int
+	checkTimeout           time.Duration
+	customTypes            []customType
+	presets                []Preset
+	passwordPolicy         *PasswordPolicy
+	maxErrors              int
+	codes                  map[string]string
+	codeStatuses           map[string]*goerr.Status
+	errorFactory           ErrorFactory
+	redactors              map[string]RedactFunc
+	logger                 *slog.Logger
+	observer               Observer
+}
+
+type customType struct {
+	fn    validator.CustomTypeFunc
+	types []any
 }
 
 // WithLocale sets the translation locale. Supported: "zh" (default), "en".
@@ -61,6 +97,7 @@ func WithLocale(locale string) Option {
 }
 
 // WithGinBinding replaces Gin's default validator engine with this instance.
+// It is process-wide; use [Verifier.GinMiddleware] to run several Verifiers.
 func WithGinBinding() Option {
 	return func(c *config) { c.useGinBinding = true }
 }
@@ -81,11 +118,35 @@ func WithTagNameFunc(fn func(reflect.StructField) string) Option {
 	return func(c *config) { c.tagNameFunc = fn }
 }
 
+// WithCheckConcurrency limits how many async checks registered with
+// [Verifier.RegisterCheck] run at once per validation. Default: 8.
+func WithCheckConcurrency(n int) Option {
+	return func(c *config) { c.checkConcurrency = n }
+}
+
+// WithCheckTimeout bounds the run time of each async check. Default: none.
+func WithCheckTimeout(d time.Duration) Option {
+	return func(c *config) { c.checkTimeout = d }
+}
+
+// WithCustomTypeFunc registers fn to convert fields of the given types
+// into the value their tags are applied to. Can be repeated.
+//
+//	verify.WithCustomTypeFunc(verify.UnwrapValuer, uuid.UUID{})
+//	verify.WithCustomTypeFunc(verify.UnwrapDecimal, decimal.Decimal{}, decimal.NullDecimal{})
+//	verify.WithCustomTypeFunc(verify.UnwrapOptional, verify.Optional[Color]{})
+//
+// The sql.Null* types and [Optional] of the basic types and time.Time are
+// registered by default.
+func WithCustomTypeFunc(fn validator.CustomTypeFunc, types ...any) Option {
+	return func(c *config) { c.customTypes = append(c.customTypes, customType{fn: fn, types: types}) }
+}
+
 // ---------- Constructor ----------
 
 // New creates a new [Verifier].
 func New(opts ...Option) (*Verifier, error) {
-	cfg := &config{locale: "zh"}
+	cfg := &config{locale: "zh", checkConcurrency: 8}
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -104,16 +165,74 @@ func New(opts ...Option) (*Verifier, error) {
 		tagFn = JSONTagName
 	}
 	v.RegisterTagNameFunc(tagFn)
+	v.RegisterCustomTypeFunc(UnwrapValuer, sqlNullTypes...)
+	v.RegisterCustomTypeFunc(UnwrapOptional, optionalTypes...)
+	for _, ct := range cfg.customTypes {
+		if ct.fn == nil || len(ct.types) == 0 {
+			return nil, errors.New("verify: custom type func and types must not be empty")
+		}
+		v.RegisterCustomTypeFunc(ct.fn, ct.types...)
+	}
 
 	trans, err := setupTranslator(cfg.locale, v)
 	if err != nil {
 		return nil, fmt.Errorf("verify: %w", err)
 	}
 
-	ver := &Verifier{validate: v, trans: trans, locale: cfg.locale}
+	if cfg.checkConcurrency < 1 {
+		return nil, fmt.Errorf("verify: check concurrency must be positive, got %d", cfg.checkConcurrency)
+	}
+	if cfg.maxErrors < 0 {
+		return nil, fmt.Errorf("verify: max errors must not be negative, got %d", cfg.maxErrors)
+	}
+
+	ver := &Verifier{
+		validate:     v,
+		trans:        trans,
+		locale:       cfg.locale,
+		tagNameFunc:  tagFn,
+		checkLimit:   cfg.checkConcurrency,
+		checkTimeout: cfg.checkTimeout,
+		maxErrors:    cfg.maxErrors,
+		errorFactory: cfg.errorFactory,
+		logger:       cfg.logger,
+		observer:     cfg.observer,
+	}
+	if ver.errorFactory == nil {
+		ver.errorFactory = DefaultErrorFactory
+	}
+	ver.checks.Store(&map[string]CheckFunc{})
+	ver.codes.Store(&codeTable{tags: map[string]string{}, statuses: map[string]*goerr.Status{}})
+	for tag, code := range cfg.codes {
+		if err := ver.RegisterCode(tag, code); err != nil {
+			return nil, err
+		}
+	}
+	ver.redactors.Store(&map[string]RedactFunc{})
+	for name, fn := range cfg.redactors {
+		if err := ver.RegisterRedactor(name, fn); err != nil {
+			return nil, err
+		}
+	}
+	for code, status := range cfg.codeStatuses {
+		if err := ver.RegisterCodeStatus(code, status); err != nil {
+			return nil, err
+		}
+	}
+	if err := ver.registerFileValidations(); err != nil {
+		return nil, fmt.Errorf("verify: %w", err)
+	}
+	if err := ver.RegisterPresets(cfg.presets...); err != nil {
+		return nil, err
+	}
+	if cfg.passwordPolicy != nil {
+		if err := ver.RegisterPasswordPolicy(*cfg.passwordPolicy); err != nil {
+			return nil, err
+		}
+	}
 
 	if cfg.useGinBinding {
-		if err := bindToGin(v); err != nil {
+		if err := bindToGin(ver); err != nil {
 			return nil, fmt.Errorf("verify: %w", err)
 		}
 	}
@@ -174,6 +293,17 @@ func setupTranslator(locale string, v *validator.Validate) (ut.Translator, error
 	if err != nil {
 		return nil, fmt.Errorf("register translations for %q: %w", locale, err)
 	}
+	if err := trans.Add(ruleTag, ruleText(locale), false); err != nil {
+		return nil, fmt.Errorf("register translations for %q: %w", locale, err)
+	}
+	if err := trans.Add(envTag, envText(locale), false); err != nil {
+		return nil, fmt.Errorf("register translations for %q: %w", locale, err)
+	}
+	for typ := range valueTexts["en"] {
+		if err := trans.Add(typ, valueText(locale, typ), false); err != nil {
+			return nil, fmt.Errorf("register translations for %q: %w", locale, err)
+		}
+	}
 	return trans, nil
 }
 
@@ -181,12 +311,46 @@ func setupTranslator(locale string, v *validator.Validate) (ut.Translator, error
 
 // Struct validates a struct.
 func (ver *Verifier) Struct(s any) error {
-	return ver.validate.Struct(s)
+	return ver.StructCtx(context.Background(), s)
 }
 
-// StructCtx validates a struct with context.
+// StructCtx validates a struct with context, then evaluates the "when"
+// rules (see [Rule]), runs the async checks registered with
+// [Verifier.RegisterCheck] on the fields that passed and calls the
+// Validate methods of the [Validatable] values that passed. With an error
+// limit, see [WithMaxErrors] and [MaxErrors], it stops as soon as the limit
+// is reached. If there are violations below the limit, the warnings of the
+// "warn" tags of the other fields come with them, apart, see
+// [Verifier.Errors]; warnings alone do not fail.
 func (ver *Verifier) StructCtx(ctx context.Context, s any) error {
-	return ver.validate.StructCtx(ctx, s)
+	ctx, ob := ver.observe(ctx, StructOperation, s)
+	limit := ver.maxErrorsFor(ctx)
+	var err error
+	if limit > 0 {
+		err = ver.structLimited(ctx, s, limit)
+	} else {
+		err = ver.validate.StructCtx(ctx, s)
+	}
+	if !limitReached(err, limit) {
+		err = ver.runRules(s, err)
+	}
+	ob.phase(ctx, PhaseTags, true)
+	if !limitReached(err, limit) {
+		err = ver.runChecks(ctx, s, err)
+		ob.phase(ctx, PhaseChecks, hasStructTag(reflect.TypeOf(s), checkTagName))
+	}
+	if !limitReached(err, limit) {
+		err = ver.runValidatables(ctx, s, err)
+		ob.phase(ctx, PhaseValidatables, hasValidatable(reflect.TypeOf(s)))
+	}
+	err = ver.runWarnings(ctx, s, ver.annotate(s, truncateErrors(err, limit)), limit)
+	var errs *Errors
+	if err != nil && (ob != nil || ver.logEnabled(ctx)) {
+		errs = ver.Errors(err) // translated once for the log and the observer
+	}
+	ver.logFailure(ctx, s, err, errs)
+	ob.end(ctx, err, errs)
+	return err
 }
 
 // Field validates a single variable against the given tag.
@@ -220,14 +384,19 @@ func (ver *Verifier) StructFilteredCtx(ctx context.Context, s any, fn validator.
 }
 
 // Map validates a map against rules. Returns nil if valid, otherwise
-// a map of field -> error (same as validator.ValidateMap).
+// a map of field -> error (same as validator.ValidateMap). A rule is a tag
+// string, a nested rule map or a [*Rule].
 func (ver *Verifier) Map(m map[string]any, rules map[string]any) map[string]any {
-	return ver.validate.ValidateMap(m, rules)
+	return ver.MapCtx(context.Background(), m, rules)
 }
 
 // MapCtx validates a map with context.
 func (ver *Verifier) MapCtx(ctx context.Context, m map[string]any, rules map[string]any) map[string]any {
-	return ver.validate.ValidateMapCtx(ctx, m, rules)
+	ctx, ob := ver.observe(ctx, MapOperation, m)
+	out := ver.validate.ValidateMapCtx(ctx, m, rules)
+	ver.runMapRules(reflect.ValueOf(m), reflect.Value{}, reflect.ValueOf(m), "", rules, out)
+	ob.endMap(ctx, out)
+	return out
 }
 
 // ---------- Registration ----------
@@ -269,6 +438,13 @@ func (ver *Verifier) RegisterStructValidation(fn validator.StructLevelFunc, type
 	ver.mu.Lock()
 	defer ver.mu.Unlock()
 	ver.validate.RegisterStructValidation(fn, types...)
+	for _, t := range types {
+		rt := reflect.TypeOf(t)
+		for rt.Kind() == reflect.Pointer {
+			rt = rt.Elem()
+		}
+		ver.structLevels.Store(rt, true)
+	}
 }
 
 // ---------- Translation Helpers ----------
@@ -292,15 +468,36 @@ func Translate(trans ut.Translator, fe validator.FieldError) string {
 	return msg
 }
 
+// translate translates fe, preferring messages loaded by [Verifier.LoadMessages].
+func (ver *Verifier) translate(fe validator.FieldError) string {
+	inner := fe
+	if w, ok := fe.(*wrappedError); ok {
+		inner = w.FieldError
+	}
+	if fe, ok := inner.(*fieldError); ok && fe.fixed {
+		return fe.msg
+	}
+	if msg, ok := ver.catalog.Load().lookup(ver.trans, fe); ok {
+		return msg
+	}
+	return fe.Translate(ver.trans)
+}
+
+// translateAll is the Verifier-aware equivalent of [validator.ValidationErrors.Translate].
+func (ver *Verifier) translateAll(errs validator.ValidationErrors) map[string]string {
+	out := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		out[fe.Namespace()] = ver.translate(fe)
+	}
+	return out
+}
+
 // RemoveTopStruct strips the top-level struct name from translated field keys.
 // "OrderParams.name" → "name"
 func RemoveTopStruct(fields map[string]string) map[string]string {
 	res := make(map[string]string, len(fields))
 	for field, msg := range fields {
-		if _, after, ok := strings.Cut(field, "."); ok {
-			field = after
-		}
-		res[field] = msg
+		res[trimTopStruct(field)] = msg
 	}
 	return res
 }
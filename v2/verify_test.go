@@ -1,6 +1,9 @@
 package verify_test
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -64,6 +67,14 @@ func TestStruct_Valid(t *testing.T) {
 	}
 }
 
+func TestStruct_Nil(t *testing.T) {
+	for _, v := range []*verify.Verifier{newVerifier(t), verify.MustNew(verify.WithFailFast())} {
+		if _, ok := errors.AsType[*validator.InvalidValidationError](v.Struct(nil)); !ok {
+			t.Fatalf("expected InvalidValidationError, got %v", v.Struct(nil))
+		}
+	}
+}
+
 func TestStructErr(t *testing.T) {
 	v := newVerifier(t)
 	p := SignUpParams{Name: "a", Email: "bad", Password: "1", RePassword: "2", Age: 200}
@@ -352,3 +363,95 @@ func TestVersion(t *testing.T) {
 	}
 	t.Logf("version: %s", verify.Version)
 }
+
+// ---------- Plural translations ----------
+
+func TestSelfRegisterPluralTranslation(t *testing.T) {
+	messages := map[string]verify.Plural{
+		"en": {One: "{0} can have at most {1} tag", Other: "{0} can have at most {1} tags"},
+		"zh": {Other: "{0}最多只能有{1}个标签"},
+	}
+	cases := []struct {
+		locale, tag, want string
+	}{
+		{"en", "max_tags=1", "can have at most 1 tag"},
+		{"en", "max_tags=2", "can have at most 2 tags"},
+		{"zh", "max_tags=1", "最多只能有1个标签"},
+	}
+	for _, tc := range cases {
+		v := verify.MustNew(verify.WithLocale(tc.locale))
+		if err := v.SelfRegisterPluralTranslation("max_tags", messages, maxTags); err != nil {
+			t.Fatal(err)
+		}
+		err := v.Field([]string{"a", "b", "c"}, tc.tag)
+		if got := v.FieldErr("tags", err).Error(); !strings.HasSuffix(got, tc.want) {
+			t.Fatalf("%s %s: expected %q in %q", tc.locale, tc.tag, tc.want, got)
+		}
+	}
+}
+
+func TestAddPluralTranslation_MissingLocale(t *testing.T) {
+	v := newVerifier(t)
+	err := v.AddPluralTranslation("len", map[string]verify.Plural{"en": {Other: "{0} len {1}"}})
+	if err == nil {
+		t.Fatal("expected error for missing zh translation")
+	}
+}
+
+func TestSelfRegisterPluralTranslation_Ordinal(t *testing.T) {
+	v := verify.MustNew(verify.WithLocale("en"))
+	err := v.SelfRegisterPluralTranslation("nth", map[string]verify.Plural{
+		"en": {One: "{0} must be the {1}st", Two: "{0} must be the {1}nd", Few: "{0} must be the {1}rd", Other: "{0} must be the {1}th", Ordinal: true},
+	}, func(validator.FieldLevel) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for param, want := range map[string]string{"1": "1st", "2": "2nd", "3": "3rd", "4": "4th", "11": "11th", "22": "22nd"} {
+		if got := v.FieldErr("rank", v.Field(0, "nth="+param)).Error(); !strings.HasSuffix(got, "must be the "+want) {
+			t.Fatalf("nth=%s: expected %q in %q", param, want, got)
+		}
+	}
+}
+
+func TestSelfRegisterPluralTranslation_Invalid(t *testing.T) {
+	v := newVerifier(t)
+	for _, messages := range []map[string]verify.Plural{
+		{"en": {Other: "{0} tags"}},                 // no zh entry
+		{"zh": {One: "{0} tag", Other: "{0} tags"}}, // zh has no One form
+	} {
+		if err := v.SelfRegisterPluralTranslation("max_tags", messages, maxTags); err == nil {
+			t.Fatalf("expected error for %v", messages)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected max_tags to stay unregistered")
+		}
+	}()
+	_ = v.Field([]string{"a"}, "max_tags=1")
+}
+
+func maxTags(fl validator.FieldLevel) bool {
+	n, err := strconv.Atoi(fl.Param())
+	return err == nil && fl.Field().Len() <= n
+}
+
+// ---------- Errors ----------
+
+func TestErrors(t *testing.T) {
+	v := newVerifier(t)
+	p := SignUpParams{Name: "a", Email: "bad", Password: "123456", RePassword: "123456", Age: 25}
+	errs := v.Errors(v.Struct(p))
+	if errs.Len() != 2 {
+		t.Fatalf("expected 2 violations, got %+v", errs)
+	}
+	if errs.Violations[0].Field != "name" || errs.Violations[0].Tag != "min" || errs.Violations[0].Param != "2" {
+		t.Fatalf("unexpected violation %+v", errs.Violations[0])
+	}
+	if errs.Error() != v.AllFieldErrors(v.Struct(p))["email"] {
+		t.Fatalf("expected first sorted message, got %q", errs.Error())
+	}
+	if v.Errors(nil) != nil {
+		t.Fatal("expected nil for nil error")
+	}
+}
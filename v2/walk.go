@@ -0,0 +1,226 @@
+package verify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// fieldNode is a struct field reached while walking a value.
+type fieldNode struct {
+	value    reflect.Value // field value, pointers not dereferenced
+	field    reflect.StructField
+	parent   reflect.Value // struct holding the field
+	outer    reflect.Value // struct holding parent, invalid at the top
+	name     string        // name from the tag name func
+	ns       string        // "Order.items[0].name"
+	structNs string        // "Order.Items[0].Name"
+}
+
+// walkFields calls visit for every exported struct field reachable from s
+// through nested structs, pointers, slices, arrays and maps, depth-first.
+// Namespaces match the ones validator reports. visit returns false to skip
+// the field's nested values.
+func (ver *Verifier) walkFields(s any, visit func(*fieldNode) bool) {
+	ver.walk(s, &walker{visit: visit})
+}
+
+// walkValues calls visit for every value reachable from s through struct
+// fields, pointers, slices, arrays and maps, depth-first, including field
+// values of non-struct types. s itself is not visited.
+func (ver *Verifier) walkValues(s any, visit func(v reflect.Value, ns, structNs string)) {
+	ver.walk(s, &walker{visit: func(*fieldNode) bool { return true }, value: visit})
+}
+
+func (ver *Verifier) walk(s any, w *walker) {
+	v := reflect.ValueOf(s)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	name := v.Type().Name()
+	w.ver, w.seen = ver, make(map[uintptr]bool)
+	w.walkStruct(v, name+".", name+".")
+}
+
+type walker struct {
+	ver   *Verifier
+	visit func(*fieldNode) bool
+	value func(v reflect.Value, ns, structNs string) // optional, see walkValues
+	seen  map[uintptr]bool                           // guards pointer cycles
+	stack []reflect.Value                            // structs being walked
+}
+
+func (w *walker) walkStruct(v reflect.Value, ns, structNs string) {
+	var outer reflect.Value
+	if len(w.stack) > 0 {
+		outer = w.stack[len(w.stack)-1]
+	}
+	w.stack = append(w.stack, v)
+	defer func() { w.stack = w.stack[:len(w.stack)-1] }()
+
+	t := v.Type()
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if !fld.IsExported() && !fld.Anonymous {
+			continue
+		}
+		name := fld.Name
+		if alt := w.ver.tagNameFunc(fld); alt != "" {
+			name = alt
+		}
+		node := &fieldNode{
+			value:    v.Field(i),
+			field:    fld,
+			parent:   v,
+			outer:    outer,
+			name:     name,
+			ns:       ns + name,
+			structNs: structNs + fld.Name,
+		}
+		if w.visit(node) {
+			w.walkValue(node.value, node.ns, node.structNs)
+		}
+	}
+}
+
+func (w *walker) walkValue(v reflect.Value, ns, structNs string) {
+	if w.value != nil {
+		w.value(v, ns, structNs)
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			if w.seen[v.Pointer()] {
+				return
+			}
+			w.seen[v.Pointer()] = true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return
+		}
+		w.walkStruct(v, ns+".", structNs+".")
+	case reflect.Slice, reflect.Array:
+		if !w.enter(v.Type().Elem()) {
+			return
+		}
+		for i := range v.Len() {
+			idx := fmt.Sprintf("[%d]", i)
+			w.walkValue(v.Index(i), ns+idx, structNs+idx)
+		}
+	case reflect.Map:
+		if !w.enter(v.Type().Elem()) {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			idx := fmt.Sprintf("[%v]", iter.Key().Interface())
+			w.walkValue(iter.Value(), ns+idx, structNs+idx)
+		}
+	}
+}
+
+// enter reports whether elements of type t need to be walked.
+func (w *walker) enter(t reflect.Type) bool {
+	return containsStruct(t) || w.value != nil && implementsValidatable(t)
+}
+
+// containsStruct reports whether values of t can hold struct fields.
+func containsStruct(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Interface:
+			return true
+		case reflect.Struct:
+			return t != timeType
+		default:
+			return false
+		}
+	}
+}
+
+type typeTag struct {
+	typ reflect.Type
+	tag string
+}
+
+var tagPresence sync.Map // typeTag → bool
+
+// hasStructTag reports whether t, or any type reachable from it, has a
+// struct field carrying the given struct tag key. Results are cached.
+func hasStructTag(t reflect.Type, key string) bool {
+	if t == nil {
+		return false
+	}
+	if found, ok := tagPresence.Load(typeTag{t, key}); ok {
+		return found.(bool)
+	}
+	found := scanFields(t, func(fld reflect.StructField) bool {
+		_, ok := fld.Tag.Lookup(key)
+		return ok
+	}, make(map[reflect.Type]bool))
+	tagPresence.Store(typeTag{t, key}, found)
+	return found
+}
+
+// scanFields reports whether match holds for a struct field of t or of
+// any type reachable from it.
+func scanFields(t reflect.Type, match func(reflect.StructField) bool, seen map[reflect.Type]bool) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+			continue
+		case reflect.Interface:
+			// Dynamic values may hold anything; assume they might.
+			return true
+		case reflect.Struct:
+		default:
+			return false
+		}
+		break
+	}
+	if t == timeType || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := range t.NumField() {
+		fld := t.Field(i)
+		if match(fld) {
+			return true
+		}
+		if scanFields(fld.Type, match, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTag splits a comma-separated struct tag value, dropping empty parts.
+func splitTag(tag string) []string {
+	parts := strings.Split(tag, ",")
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
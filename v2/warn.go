@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// warnTag is the struct tag holding "soft" rules: their failures are
+// warnings that do not fail validation.
+//
+//	type Product struct {
+//	    Price    int    `json:"price" binding:"required,max=100000" warn:"lte=50000"`
+//	    Nickname string `json:"nickname" binding:"required" warn:"printascii"`
+//	}
+//
+// The tags see the field on its own, so cross-field tags such as eqfield
+// cannot be used; aliases and presets can.
+const warnTag = "warn"
+
+// IsWarning reports whether fe was reported by a "warn" tag.
+func IsWarning(fe validator.FieldError) bool {
+	w, ok := fe.(*wrappedError)
+	return ok && w.warning
+}
+
+// Warnings returns the translated violations of the "warn" tags of s, or
+// nil if there are none. Use it to report warnings for a valid request;
+// when validation fails they come with the error, see [Errors.Warnings].
+//
+//	if err := c.ShouldBindJSON(&p); err != nil {
+//	    c.AbortWithStatusJSON(http.StatusBadRequest, v.Response(err))
+//	    return
+//	}
+//	c.JSON(http.StatusOK, gin.H{"data": p, "warnings": v.Warnings(&p)})
+func (ver *Verifier) Warnings(s any) []FieldViolation {
+	return ver.WarningsCtx(context.Background(), s)
+}
+
+// WarningsCtx is like [Verifier.Warnings] with a context.
+func (ver *Verifier) WarningsCtx(ctx context.Context, s any) []FieldViolation {
+	warnings, err := ver.warnings(ctx, s, nil)
+	if err != nil {
+		return nil
+	}
+	warnings, _ = errors.AsType[validator.ValidationErrors](ver.annotate(s, warnings))
+	var out []FieldViolation
+	for _, fe := range warnings {
+		out = append(out, ver.violation(fe))
+	}
+	return out
+}
+
+// runWarnings attaches the warnings of the fields of s that passed their
+// tags to the first violation of err, unless err holds limit violations.
+// The error keeps its type and violations, so type assertions, errors.As,
+// gin and [Verifier.AllFieldErrors] only see those; [Verifier.Errors]
+// translates the warnings too. A valid struct stays valid.
+func (ver *Verifier) runWarnings(ctx context.Context, s any, err error, limit int) error {
+	valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+	if !ok || len(valErrs) == 0 || limitReached(err, limit) || !hasStructTag(reflect.TypeOf(s), warnTag) {
+		return err
+	}
+	failed := make(map[string]bool, len(valErrs))
+	for _, fe := range valErrs {
+		failed[fe.StructNamespace()] = true
+	}
+	warnings, werr := ver.warnings(ctx, s, failed)
+	if werr != nil {
+		return werr
+	}
+	if len(warnings) == 0 {
+		return err
+	}
+	warnings, _ = errors.AsType[validator.ValidationErrors](ver.annotate(s, warnings))
+	w, ok := valErrs[0].(*wrappedError)
+	if ok {
+		c := *w
+		w = &c
+	} else {
+		w = &wrappedError{FieldError: valErrs[0], ns: valErrs[0].Namespace(), structNs: valErrs[0].StructNamespace()}
+	}
+	w.warnings = warnings
+	out := slices.Clone(valErrs)
+	out[0] = w
+	return out
+}
+
+// warnings validates the "warn" tags of the fields of s, skipping the
+// fields in failed.
+func (ver *Verifier) warnings(ctx context.Context, s any, failed map[string]bool) (validator.ValidationErrors, error) {
+	var out validator.ValidationErrors
+	var walkErr error
+	ver.walkFields(s, func(n *fieldNode) bool {
+		tags, ok := n.field.Tag.Lookup(warnTag)
+		if !ok || tags == "" || failed[n.structNs] || !n.value.CanInterface() || walkErr != nil {
+			return true
+		}
+		// The field is validated as the only field of a struct with the
+		// same name and tags, so messages name it like binding tags do.
+		fld := reflect.StructField{
+			Name: n.field.Name,
+			Type: n.field.Type,
+			Tag:  reflect.StructTag(`binding:` + strconv.Quote(tags) + ` ` + string(n.field.Tag)),
+		}
+		v := reflect.New(reflect.StructOf([]reflect.StructField{fld})).Elem()
+		v.Field(0).Set(n.value)
+		err := ver.validate.StructCtx(ctx, v.Interface())
+		valErrs, ok := errors.AsType[validator.ValidationErrors](err)
+		if err != nil && !ok {
+			walkErr = err
+			return false
+		}
+		for _, fe := range valErrs {
+			out = append(out, &wrappedError{
+				FieldError: fe,
+				ns:         n.ns + strings.TrimPrefix(fe.Namespace(), n.name),
+				structNs:   n.structNs + strings.TrimPrefix(fe.StructNamespace(), n.field.Name),
+				warning:    true,
+			})
+		}
+		return true
+	})
+	return out, walkErr
+}
@@ -0,0 +1,86 @@
+package verify_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	verify "github.com/gtkit/verify/v2"
+)
+
+type Listing struct {
+	Title    string   `json:"title" binding:"required"`
+	Price    int      `json:"price" binding:"required,max=100000" warn:"lte=50000"`
+	Nickname string   `json:"nickname" warn:"printascii"`
+	Tags     []string `json:"tags" warn:"max=3,dive,alpha"`
+}
+
+func TestWarnings(t *testing.T) {
+	v := newVerifier(t)
+	l := Listing{Title: "bike", Price: 80000, Nickname: "小明", Tags: []string{"a", "b2"}}
+
+	if err := v.Struct(&l); err != nil {
+		t.Fatalf("warnings must not fail validation, got %v", err)
+	}
+	got := map[string]string{}
+	for _, fv := range v.Warnings(&l) {
+		if !fv.Warning {
+			t.Fatalf("expected a flagged warning, got %+v", fv)
+		}
+		got[fv.Field] = fv.Tag
+	}
+	want := map[string]string{"price": "lte", "nickname": "printascii", "tags[1]": "alpha"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for field, tag := range want {
+		if got[field] != tag {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	l.Price = 100
+	l.Nickname, l.Tags = "bob", nil
+	if w := v.Warnings(&l); w != nil {
+		t.Fatalf("expected no warnings, got %+v", w)
+	}
+}
+
+func TestWarnings_WithErrors(t *testing.T) {
+	v := newVerifier(t)
+	l := Listing{Price: 200000, Nickname: "小明"}
+	err := v.Struct(&l)
+
+	errs := v.Errors(err)
+	if errs.Len() != 2 || len(errs.Warnings) != 1 || errs.Warnings[0].Field != "nickname" {
+		t.Fatalf("expected 2 violations and the nickname warning, got %+v / %+v", errs.Violations, errs.Warnings)
+	}
+	if errs.Warnings[0].Message != "nickname必须只包含可打印的ascii字符" {
+		t.Fatalf("unexpected warning message %q", errs.Warnings[0].Message)
+	}
+	valErrs, ok := err.(validator.ValidationErrors)
+	if !ok || len(valErrs) != 2 || verify.IsWarning(valErrs[0]) || verify.IsWarning(valErrs[1]) {
+		t.Fatalf("expected only the violations in the error, got %T %v", err, err)
+	}
+	if all := v.AllFieldErrors(err); len(all) != 2 {
+		t.Fatalf("expected no warnings in AllFieldErrors, got %v", all)
+	}
+
+	// StructErr ignores warnings even when they sort first.
+	l = Listing{Nickname: "小明", Price: 1}
+	if msg := v.StructErr(v.Struct(&l)).Error(); !strings.HasSuffix(msg, "title为必填字段") {
+		t.Fatalf("unexpected StructErr %q", msg)
+	}
+	if resp := v.Response(v.Struct(&l)); len(resp.Errors) != 1 || len(resp.Warnings) != 1 {
+		t.Fatalf("expected errors and warnings apart, got %+v", resp)
+	}
+}
+
+func TestWarnings_FailFast(t *testing.T) {
+	v := verify.MustNew(verify.WithFailFast())
+	errs := v.Errors(v.Struct(&Listing{Price: 80000, Nickname: "小明"}))
+	if errs.Len() != 1 || errs.Warnings != nil {
+		t.Fatalf("expected one violation and no warnings, got %+v / %+v", errs.Violations, errs.Warnings)
+	}
+}